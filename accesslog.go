@@ -0,0 +1,98 @@
+package lile
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AccessLogConfig controls the access log written for every RPC
+type AccessLogConfig struct {
+	// Disabled turns the access log off
+	Disabled bool
+
+	// SampleRate is the fraction, from 0 to 1, of successful calls that are
+	// logged. Failed and slow calls are always logged
+	SampleRate float64
+
+	// SlowThreshold logs calls that take longer as warnings, zero disables
+	// it
+	SlowThreshold time.Duration
+}
+
+// serverFaults are codes that mean the server, rather than the caller,
+// is at fault
+var serverFaults = map[codes.Code]bool{
+	codes.Unknown:          true,
+	codes.DeadlineExceeded: true,
+	codes.Unimplemented:    true,
+	codes.Internal:         true,
+	codes.Unavailable:      true,
+	codes.DataLoss:         true,
+}
+
+// AccessLogUnaryInterceptor logs unary RPCs to the service's Logger
+func (s *Service) AccessLogUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		s.logAccess(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// AccessLogStreamInterceptor logs streaming RPCs to the service's Logger
+// once they finish
+func (s *Service) AccessLogStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		s.logAccess(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (s *Service) logAccess(ctx context.Context, method string, start time.Time, err error) {
+	d := time.Since(start)
+	code := status.Code(err)
+	cfg := s.AccessLog
+
+	slow := cfg.SlowThreshold > 0 && d > cfg.SlowThreshold
+	if err == nil && !slow && rand.Float64() >= cfg.SampleRate {
+		return
+	}
+
+	fields := []interface{}{
+		"method", method,
+		"code", code.String(),
+		"duration", d,
+	}
+
+	if addr := peerAddr(ctx); addr != "" {
+		fields = append(fields, "peer", addr)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields = append(fields, "trace_id", sc.TraceID().String())
+	}
+
+	if err != nil {
+		fields = append(fields, "error", status.Convert(err).Message())
+	}
+
+	switch {
+	case serverFaults[code]:
+		s.log().Error("lile: rpc failed", fields...)
+	case slow:
+		s.log().Warn("lile: slow rpc", fields...)
+	default:
+		s.log().Info("lile: rpc", fields...)
+	}
+}
@@ -0,0 +1,86 @@
+package lile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func nextAccessLog(t *testing.T, l *recordingLogger) logEntry {
+	for {
+		select {
+		case e := <-l.entries:
+			if _, ok := e.fields["method"]; ok {
+				return e
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no access log written")
+		}
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	logger := newRecordingLogger()
+
+	s := testService(t, "access-log")
+	s.Logger = logger
+	s.Telemetry = &Telemetry{TracerProvider: sdktrace.NewTracerProvider()}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+
+	e := nextAccessLog(t, logger)
+	assert.Equal(t, "info", e.level)
+	assert.Equal(t, "/grpc.health.v1.Health/Check", e.fields["method"])
+	assert.Equal(t, "OK", e.fields["code"])
+	assert.NotEmpty(t, e.fields["peer"])
+	assert.Len(t, e.fields["trace_id"], 32)
+	assert.IsType(t, time.Duration(0), e.fields["duration"])
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestAccessLogSampling(t *testing.T) {
+	logger := newRecordingLogger()
+
+	s := NewService("access-log-sampling")
+	s.Logger = logger
+	s.AccessLog = AccessLogConfig{SampleRate: 0, SlowThreshold: 100 * time.Millisecond}
+
+	// Fast successful calls are sampled out
+	s.logAccess(context.Background(), "/pkg.Svc/Fast", time.Now(), nil)
+
+	// Slow and failed calls are always logged
+	s.logAccess(context.Background(), "/pkg.Svc/Slow", time.Now().Add(-time.Second), nil)
+	s.logAccess(context.Background(), "/pkg.Svc/Failed", time.Now(),
+		status.Error(codes.Internal, "boom"))
+
+	e := nextAccessLog(t, logger)
+	assert.Equal(t, "warn", e.level)
+	assert.Equal(t, "/pkg.Svc/Slow", e.fields["method"])
+
+	e = nextAccessLog(t, logger)
+	assert.Equal(t, "error", e.level)
+	assert.Equal(t, "Internal", e.fields["code"])
+	assert.Empty(t, logger.entries)
+}
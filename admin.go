@@ -0,0 +1,68 @@
+package lile
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HandleAdmin registers a handler on the admin server, which also serves
+// /metrics, /healthz, /readyz and, when enabled, pprof
+func (s *Service) HandleAdmin(pattern string, handler http.Handler) {
+	if s.adminHandlers == nil {
+		s.adminHandlers = map[string]http.Handler{}
+	}
+
+	s.adminHandlers[pattern] = handler
+}
+
+// adminMux creates a private mux for the admin endpoints, a new mux is
+// created each time so running a service twice doesn't register a pattern
+// twice
+func (s *Service) adminMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", s.readyz)
+
+	if s.EnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	for pattern, handler := range s.adminHandlers {
+		mux.Handle(pattern, handler)
+	}
+
+	return mux
+}
+
+// readyz reports whether the service as a whole is SERVING according to
+// the health service
+func (s *Service) readyz(w http.ResponseWriter, r *http.Request) {
+	// Probes arrive while Run is still creating the health server
+	s.mu.Lock()
+	hs := s.HealthServer
+	s.mu.Unlock()
+
+	if hs == nil {
+		http.Error(w, "not serving", http.StatusServiceUnavailable)
+		return
+	}
+
+	res, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		http.Error(w, "not serving", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Write([]byte("ok"))
+}
@@ -0,0 +1,101 @@
+package lile
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminServer(t *testing.T) {
+	s := testService(t, "admin")
+	s.HandleAdmin("/flags", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("flags"))
+	}))
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	base := "http://" + s.PrometheusConfig.Address()
+	for path, want := range map[string]int{
+		"/metrics":      http.StatusOK,
+		"/healthz":      http.StatusOK,
+		"/readyz":       http.StatusOK,
+		"/flags":        http.StatusOK,
+		"/debug/pprof/": http.StatusNotFound,
+	} {
+		code, _ := httpGet(t, base+path)
+		assert.Equal(t, want, code, path)
+	}
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestAdminPprof(t *testing.T) {
+	s := NewService("pprof")
+	s.EnablePprof = true
+
+	rec := httptest.NewRecorder()
+	s.adminMux().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Not ready until the health service has started
+	rec = httptest.NewRecorder()
+	s.adminMux().ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestReadyzDuringStartup(t *testing.T) {
+	s := testService(t, "readyz-startup")
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	// Probes race the creation of the health server
+	for {
+		rec := httptest.NewRecorder()
+		s.adminMux().ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+		if rec.Code == http.StatusOK {
+			break
+		}
+	}
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestMetricsPortInUse(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+
+	s := testService(t, "metrics-in-use")
+	s.PrometheusConfig.Port = l.Addr().(*net.TCPAddr).Port
+
+	err = s.Run()
+	assert.NotNil(t, err)
+	assert.Equal(t, StateStopped, s.State())
+	assert.Nil(t, s.GRPCServer)
+}
+
+func TestMetricsOptional(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+
+	s := testService(t, "metrics-optional")
+	s.PrometheusConfig.Port = l.Addr().(*net.TCPAddr).Port
+	s.MetricsOptional = true
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+	assert.Nil(t, s.PrometheusServer)
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
@@ -0,0 +1,224 @@
+package lile
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoCredentials is returned by authenticators when the call carries no
+// credentials they understand
+var ErrNoCredentials = errors.New("lile: no credentials")
+
+// alwaysExempt are endpoints that never need authentication, so probes and
+// tooling keep working
+var alwaysExempt = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// Principal is an authenticated caller
+type Principal struct {
+	// Subject identifies the caller, e.g. the JWT subject or API key name
+	Subject string
+
+	// Roles are granted to the caller and checked by authorization
+	Roles []string
+
+	// Claims holds anything else the authenticator knows, e.g. JWT claims
+	Claims map[string]interface{}
+}
+
+// HasRole returns true if the principal has been granted the role
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a context carrying the principal
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal authenticated for the call
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Authenticator authenticates a call from its incoming metadata
+type Authenticator interface {
+	// Authenticate returns the caller, or an error when the credentials are
+	// missing or invalid. Errors that aren't gRPC statuses are returned to
+	// the caller as codes.Unauthenticated
+	Authenticate(ctx context.Context) (*Principal, error)
+}
+
+// AuthConfig configures authentication of every call
+type AuthConfig struct {
+	// Authenticator enables authentication when set
+	Authenticator Authenticator
+
+	// Exempt lists full method names, or service prefixes ending in a
+	// slash like /pkg.Service/, that don't need authentication. Health and
+	// reflection endpoints are always exempt
+	Exempt []string
+
+	// Policy is checked against the authenticated principal, methods it
+	// marks public are exempt from authentication
+	Policy AuthPolicy
+}
+
+func (c AuthConfig) exempt(method string) bool {
+	if c.Policy[method].Public {
+		return true
+	}
+
+	for _, lists := range [][]string{alwaysExempt, c.Exempt} {
+		for _, e := range lists {
+			if method == e || (strings.HasSuffix(e, "/") && strings.HasPrefix(method, e)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c AuthConfig) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if c.exempt(method) {
+		return ctx, nil
+	}
+
+	p, err := c.Authenticator.Authenticate(ctx)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Authenticators must return a caller, a nil one would pass as
+	// authenticated without any roles
+	if p == nil {
+		return nil, status.Error(codes.Unauthenticated, "lile: no principal for the call")
+	}
+
+	return ContextWithPrincipal(ctx, p), nil
+}
+
+// AuthUnaryInterceptor authenticates unary calls and puts the principal in
+// the handler's context
+func AuthUnaryInterceptor(c AuthConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := c.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor authenticates streams and puts the principal in
+// the stream's context
+func AuthStreamInterceptor(c AuthConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, err := c.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextServerStream replaces the context of a stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// APIKeyAuthenticator authenticates callers by a static API key
+type APIKeyAuthenticator struct {
+	// Header is the metadata header holding the key, it defaults to
+	// x-api-key
+	Header string
+
+	keys map[[sha256.Size]byte]*Principal
+}
+
+// NewAPIKeyAuthenticator creates an authenticator for the keys, each mapped
+// to the principal it authenticates as
+func NewAPIKeyAuthenticator(keys map[string]*Principal) *APIKeyAuthenticator {
+	a := &APIKeyAuthenticator{keys: map[[sha256.Size]byte]*Principal{}}
+
+	// Keys are looked up by hash so lookups don't leak how much of a key
+	// was right
+	for k, p := range keys {
+		a.keys[sha256.Sum256([]byte(k))] = p
+	}
+
+	return a
+}
+
+// Authenticate implements Authenticator
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	header := a.Header
+	if header == "" {
+		header = "x-api-key"
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(header)
+	if len(keys) == 0 || keys[0] == "" {
+		return nil, ErrNoCredentials
+	}
+
+	p, ok := a.keys[sha256.Sum256([]byte(keys[0]))]
+	if !ok {
+		return nil, errors.New("lile: invalid API key")
+	}
+
+	return p, nil
+}
+
+// AnyAuthenticator tries each authenticator in turn, moving on when one
+// finds no credentials it understands. It lets services accept, say, both
+// JWTs and API keys
+func AnyAuthenticator(as ...Authenticator) Authenticator {
+	return anyAuthenticator(as)
+}
+
+type anyAuthenticator []Authenticator
+
+func (as anyAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	for _, a := range as {
+		p, err := a.Authenticate(ctx)
+		if err != ErrNoCredentials {
+			return p, err
+		}
+	}
+
+	return nil, ErrNoCredentials
+}
@@ -0,0 +1,269 @@
+package lile
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeJWKS serves a key set that tests can rotate
+type fakeJWKS struct {
+	mu   sync.Mutex
+	keys []map[string]string
+}
+
+func (f *fakeJWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	json.NewEncoder(w).Encode(map[string]interface{}{"keys": f.keys})
+}
+
+func (f *fakeJWKS) set(keys ...map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = keys
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, k *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid, "kty": "RSA", "use": "sig",
+		"n": b64(k.N.Bytes()),
+		"e": b64(big.NewInt(int64(k.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, k *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid, "kty": "EC", "crv": "P-256",
+		"x": b64(k.X.Bytes()),
+		"y": b64(k.Y.Bytes()),
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	tok := jwt.NewWithClaims(method, claims)
+	tok.Header["kid"] = kid
+
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func bearer(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "alex",
+		"iss":   "https://issuer.example.com",
+		"aud":   "orders",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"admin", "reader"},
+	}
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	jwks := &fakeJWKS{}
+	jwks.set(rsaJWK("rsa-1", rsaKey))
+	srv := httptest.NewServer(jwks)
+	defer srv.Close()
+
+	a, err := NewJWTAuthenticator(JWTConfig{
+		JWKS:     srv.URL,
+		Issuer:   "https://issuer.example.com",
+		Audience: "orders",
+	})
+	assert.Nil(t, err)
+
+	p, err := a.Authenticate(bearer(signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims())))
+	assert.Nil(t, err)
+	assert.Equal(t, "alex", p.Subject)
+	assert.True(t, p.HasRole("admin"))
+	assert.False(t, p.HasRole("owner"))
+
+	// A rotated key is fetched the first time it's seen
+	jwks.set(rsaJWK("rsa-1", rsaKey), ecJWK("ec-1", ecKey))
+	_, err = a.Authenticate(bearer(signToken(t, jwt.SigningMethodES256, "ec-1", ecKey, validClaims())))
+	assert.Nil(t, err)
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	wrongAudience := validClaims()
+	wrongAudience["aud"] = "payments"
+
+	noExpiry := validClaims()
+	delete(noExpiry, "exp")
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	for name, token := range map[string]string{
+		"expired":        signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, expired),
+		"wrong audience": signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, wrongAudience),
+		"no expiry":      signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, noExpiry),
+		"wrong key":      signToken(t, jwt.SigningMethodRS256, "rsa-1", otherKey, validClaims()),
+		"unknown key":    signToken(t, jwt.SigningMethodRS256, "rsa-2", otherKey, validClaims()),
+		"hmac":           signToken(t, jwt.SigningMethodHS256, "rsa-1", []byte("secret"), validClaims()),
+	} {
+		_, err := a.Authenticate(bearer(token))
+		assert.NotNil(t, err, name)
+	}
+
+	_, err = a.Authenticate(context.Background())
+	assert.Equal(t, ErrNoCredentials, err)
+}
+
+func TestJWTAuthenticatorFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	f, err := ioutil.TempFile("", "jwks")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name())
+
+	json.NewEncoder(f).Encode(map[string]interface{}{
+		"keys": []map[string]string{rsaJWK("file-1", key)},
+	})
+	f.Close()
+
+	a, err := NewJWTAuthenticator(JWTConfig{JWKS: f.Name()})
+	assert.Nil(t, err)
+
+	p, err := a.Authenticate(bearer(signToken(t, jwt.SigningMethodRS256, "file-1", key, validClaims())))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"admin", "reader"}, p.Roles)
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	a := NewAPIKeyAuthenticator(map[string]*Principal{
+		"s3cret": {Subject: "billing", Roles: []string{"reader"}},
+	})
+
+	key := func(k string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", k))
+	}
+
+	p, err := a.Authenticate(key("s3cret"))
+	assert.Nil(t, err)
+	assert.Equal(t, "billing", p.Subject)
+
+	_, err = a.Authenticate(key("guess"))
+	assert.NotNil(t, err)
+
+	_, err = a.Authenticate(context.Background())
+	assert.Equal(t, ErrNoCredentials, err)
+}
+
+func TestAuthInterceptors(t *testing.T) {
+	cfg := AuthConfig{
+		Authenticator: AnyAuthenticator(
+			&JWTAuthenticator{},
+			NewAPIKeyAuthenticator(map[string]*Principal{"s3cret": {Subject: "billing"}}),
+		),
+		Exempt: []string{"/pkg.Public/"},
+	}
+
+	var got *Principal
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = PrincipalFromContext(ctx)
+		return nil, nil
+	}
+
+	call := func(ctx context.Context, method string) error {
+		_, err := AuthUnaryInterceptor(cfg)(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "s3cret"))
+	assert.Nil(t, call(ctx, "/pkg.Orders/Get"))
+	assert.Equal(t, "billing", got.Subject)
+
+	err := call(context.Background(), "/pkg.Orders/Get")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	for _, method := range []string{"/pkg.Public/List", "/grpc.health.v1.Health/Check"} {
+		got = nil
+		assert.Nil(t, call(context.Background(), method), method)
+		assert.Nil(t, got)
+	}
+
+	var streamed *Principal
+	err = AuthStreamInterceptor(cfg)(nil, &contextServerStream{ctx: ctx},
+		&grpc.StreamServerInfo{FullMethod: "/pkg.Orders/Watch"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			streamed, _ = PrincipalFromContext(ss.Context())
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, "billing", streamed.Subject)
+}
+
+type nilAuthenticator struct{}
+
+func (nilAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	return nil, nil
+}
+
+func TestAuthNilPrincipal(t *testing.T) {
+	cfg := AuthConfig{Authenticator: nilAuthenticator{}}
+	called := false
+	_, err := AuthUnaryInterceptor(cfg)(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Orders/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.False(t, called)
+}
+
+func TestServiceAuth(t *testing.T) {
+	s := testService(t, "auth")
+	s.Auth = AuthConfig{Authenticator: NewAPIKeyAuthenticator(nil)}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	// Health checks don't need credentials
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, s, ""))
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
@@ -0,0 +1,74 @@
+package lile
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MethodPolicy is the authorization policy of a method, generated from its
+// (lile.auth) option
+type MethodPolicy struct {
+	// Roles lists the roles allowed to call the method, callers need at
+	// least one of them. No roles means any authenticated caller is allowed
+	Roles []string
+
+	// Public methods can be called without authenticating
+	Public bool
+}
+
+// AuthPolicy maps full method names, e.g. /pkg.Service/Method, to their
+// policy. protoc-gen-lile-server generates one as server.AuthPolicy
+type AuthPolicy map[string]MethodPolicy
+
+// authorize checks the principal in the context against the method's policy
+func (p AuthPolicy) authorize(ctx context.Context, method string) error {
+	rule, ok := p[method]
+	if !ok || rule.Public || len(rule.Roles) == 0 {
+		return nil
+	}
+
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated,
+			"lile: %s requires authentication", method)
+	}
+
+	for _, r := range rule.Roles {
+		if principal.HasRole(r) {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied,
+		"lile: %s requires one of the roles %s", method, strings.Join(rule.Roles, ", "))
+}
+
+// AuthorizationUnaryInterceptor rejects unary calls from principals without
+// a role the policy requires, it must run after authentication
+func AuthorizationUnaryInterceptor(p AuthPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := p.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizationStreamInterceptor rejects streams like
+// AuthorizationUnaryInterceptor
+func AuthorizationStreamInterceptor(p AuthPolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := p.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
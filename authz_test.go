@@ -0,0 +1,70 @@
+package lile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorization(t *testing.T) {
+	policy := AuthPolicy{
+		"/pkg.Orders/Delete": {Roles: []string{"admin", "owner"}},
+		"/pkg.Orders/List":   {Public: true},
+	}
+
+	call := func(p *Principal, method string) error {
+		ctx := context.Background()
+		if p != nil {
+			ctx = ContextWithPrincipal(ctx, p)
+		}
+
+		_, err := AuthorizationUnaryInterceptor(policy)(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		return err
+	}
+
+	reader := &Principal{Subject: "alex", Roles: []string{"reader"}}
+	owner := &Principal{Subject: "sam", Roles: []string{"reader", "owner"}}
+
+	assert.Nil(t, call(owner, "/pkg.Orders/Delete"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(reader, "/pkg.Orders/Delete")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(nil, "/pkg.Orders/Delete")))
+
+	// Methods without roles only need authentication, if any
+	assert.Nil(t, call(nil, "/pkg.Orders/List"))
+	assert.Nil(t, call(reader, "/pkg.Orders/Get"))
+
+	err := AuthorizationStreamInterceptor(policy)(nil,
+		&contextServerStream{ctx: ContextWithPrincipal(context.Background(), reader)},
+		&grpc.StreamServerInfo{FullMethod: "/pkg.Orders/Delete"},
+		func(srv interface{}, ss grpc.ServerStream) error { return nil })
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthPolicyPublicMethods(t *testing.T) {
+	cfg := AuthConfig{
+		Authenticator: NewAPIKeyAuthenticator(nil),
+		Policy:        AuthPolicy{"/pkg.Orders/List": {Public: true}},
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	_, err := AuthUnaryInterceptor(cfg)(ctx, nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Orders/List"}, handler)
+	assert.Nil(t, err)
+
+	_, err = AuthUnaryInterceptor(cfg)(ctx, nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Orders/Get"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -1,6 +1,10 @@
 package lile
 
-import "github.com/spf13/cobra"
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
 
 // BaseCommand provides the basic flags vars for running a service
 func BaseCommand(serviceName, shortDescription string) *cobra.Command {
@@ -20,7 +24,91 @@ func BaseCommand(serviceName, shortDescription string) *cobra.Command {
 		&service.Config.Port,
 		"grpc-port",
 		8000,
-		"gRPC port",
+		"gRPC port, 0 picks a free port",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.Config.Socket,
+		"grpc-socket",
+		"",
+		"Unix socket path for the gRPC service, instead of host and port",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&service.Config.Systemd,
+		"grpc-systemd",
+		false,
+		"Inherit the gRPC socket from systemd socket activation",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.Config.TLSCert,
+		"grpc-tls-cert",
+		"",
+		"Path to the gRPC TLS certificate, enables TLS",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.Config.TLSKey,
+		"grpc-tls-key",
+		"",
+		"Path to the gRPC TLS private key",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.Config.TLSClientCA,
+		"grpc-tls-client-ca",
+		"",
+		"Path to a CA bundle for client certificates, enables mutual TLS",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.ClientConfig.TLSRootCA,
+		"client-tls-root-ca",
+		"",
+		"Path to a CA bundle for verifying the services this one calls, enables client TLS",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.ClientConfig.TLSCert,
+		"client-tls-cert",
+		"",
+		"Path to the client certificate presented to services that require mutual TLS",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.ClientConfig.TLSKey,
+		"client-tls-key",
+		"",
+		"Path to the client certificate's private key",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&service.EnableReflection,
+		"grpc-reflection",
+		false,
+		"Enable the gRPC server reflection service",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.GatewayConfig.Host,
+		"gateway-host",
+		"0.0.0.0",
+		"HTTP/JSON gateway hostname",
+	)
+
+	command.PersistentFlags().IntVar(
+		&service.GatewayConfig.Port,
+		"gateway-port",
+		8080,
+		"HTTP/JSON gateway port",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&service.SinglePort,
+		"single-port",
+		false,
+		"Serve gRPC, metrics and HTTP on the gRPC port",
 	)
 
 	command.PersistentFlags().StringVar(
@@ -37,5 +125,81 @@ func BaseCommand(serviceName, shortDescription string) *cobra.Command {
 		"Prometheus metrics port",
 	)
 
+	command.PersistentFlags().BoolVar(
+		&service.MetricsOptional,
+		"metrics-optional",
+		false,
+		"Keep running when the metrics port can't be bound",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&service.EnablePprof,
+		"pprof",
+		false,
+		"Serve pprof on the metrics port under /debug/pprof/",
+	)
+
+	command.PersistentFlags().Float64Var(
+		&service.AccessLog.SampleRate,
+		"access-log-sample-rate",
+		1,
+		"Fraction of successful RPCs written to the access log",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&service.AccessLog.SlowThreshold,
+		"access-log-slow-threshold",
+		0,
+		"Log RPCs slower than this as warnings",
+	)
+
+	command.PersistentFlags().Var(
+		rateLimitsValue{service.RateLimiter},
+		"rate-limit",
+		"Rate limits per caller as method=rate[:burst], * applies to every method",
+	)
+
+	command.PersistentFlags().StringVar(
+		&service.RateLimiter.Header,
+		"rate-limit-header",
+		"",
+		"Metadata header identifying callers for rate limits, the peer IP is used otherwise",
+	)
+
+	command.PersistentFlags().IntVar(
+		&service.Concurrency.MaxLimit,
+		"concurrency-limit",
+		0,
+		"Most concurrent RPCs allowed before shedding load, 0 disables adaptive limiting",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&service.Concurrency.LatencyTarget,
+		"concurrency-latency-target",
+		100*time.Millisecond,
+		"Latency above which the concurrency limit backs off",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&service.Deadlines.Default,
+		"default-timeout",
+		0,
+		"Deadline of RPCs without their own timeout, 0 disables it",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&service.PreStopDelay,
+		"shutdown-delay",
+		0,
+		"Time to wait after marking the service NOT_SERVING before draining",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&service.ShutdownTimeout,
+		"shutdown-timeout",
+		30*time.Second,
+		"Time to wait for in-flight RPCs before stopping them",
+	)
+
 	return command
 }
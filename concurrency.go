@@ -0,0 +1,205 @@
+package lile
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PriorityHeader is the metadata header that marks a call as critical when
+// set to "critical" and ConcurrencyConfig.TrustPriorityHeader is set,
+// critical calls are never shed
+const PriorityHeader = "x-lile-priority"
+
+var (
+	concurrencyLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lile_concurrency_limit",
+		Help: "Current adaptive limit of concurrent RPCs.",
+	}, []string{"service"})
+
+	concurrencyInflight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lile_concurrency_inflight",
+		Help: "RPCs currently in flight.",
+	}, []string{"service"})
+
+	concurrencyShedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lile_concurrency_shed_total",
+		Help: "RPCs rejected with Unavailable because the concurrency limit was reached.",
+	}, []string{"service"})
+)
+
+func init() {
+	prometheus.MustRegister(concurrencyLimit, concurrencyInflight, concurrencyShedTotal)
+}
+
+// ConcurrencyConfig configures adaptive concurrency limiting. The limit of
+// concurrent RPCs grows by one each time a full limit's worth of calls
+// succeed within LatencyTarget, and is cut by 10% whenever a call is slower
+// or runs out of time. Calls over the limit are shed with codes.Unavailable
+type ConcurrencyConfig struct {
+	// MaxLimit is the most concurrent RPCs ever allowed, zero disables the
+	// limiter. The limit starts here and backs off under load
+	MaxLimit int
+
+	// MinLimit is the least the limit backs off to, it defaults to 1
+	MinLimit int
+
+	// LatencyTarget is the latency above which the server is considered
+	// overloaded, it defaults to 100ms
+	LatencyTarget time.Duration
+
+	// CriticalMethods are full method names that are never shed. Health
+	// checks are always critical
+	CriticalMethods []string
+
+	// TrustPriorityHeader lets callers mark calls as critical with
+	// PriorityHeader. Any caller can set the header, so only enable it when
+	// every caller is trusted, e.g. behind a proxy that strips it
+	TrustPriorityHeader bool
+}
+
+type concurrencyLimiter struct {
+	service  string
+	min, max float64
+	target   time.Duration
+	critical map[string]bool
+	trusted  bool
+
+	mu       sync.Mutex
+	limit    float64
+	inflight int
+}
+
+func newConcurrencyLimiter(service string, cfg ConcurrencyConfig) *concurrencyLimiter {
+	l := &concurrencyLimiter{
+		service:  service,
+		min:      float64(cfg.MinLimit),
+		max:      float64(cfg.MaxLimit),
+		target:   cfg.LatencyTarget,
+		critical: map[string]bool{},
+		trusted:  cfg.TrustPriorityHeader,
+		limit:    float64(cfg.MaxLimit),
+	}
+
+	if l.min < 1 {
+		l.min = 1
+	}
+	if l.max < l.min {
+		l.max, l.limit = l.min, l.min
+	}
+	if l.target <= 0 {
+		l.target = 100 * time.Millisecond
+	}
+
+	for _, m := range cfg.CriticalMethods {
+		l.critical[m] = true
+	}
+
+	concurrencyLimit.WithLabelValues(service).Set(l.limit)
+	return l
+}
+
+// UnaryInterceptor sheds unary calls over the limit and adjusts the limit
+// from the latency of the rest
+func (l *concurrencyLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if !l.acquire(ctx, info.FullMethod) {
+			return nil, l.shed()
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		l.release(time.Since(start), err, true)
+		return resp, err
+	}
+}
+
+// StreamInterceptor sheds streams over the limit. Streams hold a slot while
+// open but, being long lived, their duration doesn't adjust the limit
+func (l *concurrencyLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if !l.acquire(ss.Context(), info.FullMethod) {
+			return l.shed()
+		}
+
+		err := handler(srv, ss)
+		l.release(0, err, false)
+		return err
+	}
+}
+
+func (l *concurrencyLimiter) isCritical(ctx context.Context, method string) bool {
+	if l.critical[method] || strings.HasPrefix(method, "/grpc.health.v1.Health/") {
+		return true
+	}
+
+	if !l.trusted {
+		return false
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(PriorityHeader) {
+		if v == "critical" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *concurrencyLimiter) acquire(ctx context.Context, method string) bool {
+	critical := l.isCritical(ctx, method)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !critical && l.inflight >= int(l.limit) {
+		return false
+	}
+
+	l.inflight++
+	concurrencyInflight.WithLabelValues(l.service).Set(float64(l.inflight))
+	return true
+}
+
+func (l *concurrencyLimiter) release(d time.Duration, err error, sample bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if sample {
+		code := status.Code(err)
+		switch {
+		case d > l.target || code == codes.DeadlineExceeded:
+			// Multiplicative decrease
+			l.limit *= 0.9
+			if l.limit < l.min {
+				l.limit = l.min
+			}
+		case float64(l.inflight) >= l.limit/2:
+			// Additive increase, only while the limit is being used
+			l.limit += 1 / l.limit
+			if l.limit > l.max {
+				l.limit = l.max
+			}
+		}
+
+		concurrencyLimit.WithLabelValues(l.service).Set(l.limit)
+	}
+
+	l.inflight--
+	concurrencyInflight.WithLabelValues(l.service).Set(float64(l.inflight))
+}
+
+func (l *concurrencyLimiter) shed() error {
+	concurrencyShedTotal.WithLabelValues(l.service).Inc()
+	return status.Error(codes.Unavailable, "lile: server is overloaded, try again later")
+}
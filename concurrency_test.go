@@ -0,0 +1,134 @@
+package lile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestConcurrencyLimiterSheds(t *testing.T) {
+	l := newConcurrencyLimiter("shed", ConcurrencyConfig{
+		MaxLimit:            2,
+		CriticalMethods:     []string{"/pkg.Svc/Critical"},
+		TrustPriorityHeader: true,
+	})
+	intercept := l.UnaryInterceptor()
+	shed := testutil.ToFloat64(concurrencyShedTotal.WithLabelValues("shed"))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	}
+
+	call := func(ctx context.Context, method string, handler grpc.UnaryHandler) error {
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	done := make(chan error, 4)
+	for i := 0; i < 2; i++ {
+		go func() { done <- call(context.Background(), "/pkg.Svc/Get", blocking) }()
+		<-started
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(concurrencyInflight.WithLabelValues("shed")))
+
+	err := call(context.Background(), "/pkg.Svc/Get", blocking)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, shed+1, testutil.ToFloat64(concurrencyShedTotal.WithLabelValues("shed")))
+
+	// Health checks, critical methods and critical calls are never shed
+	critical := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(PriorityHeader, "critical"))
+	for _, c := range []struct {
+		ctx    context.Context
+		method string
+	}{
+		{context.Background(), "/grpc.health.v1.Health/Check"},
+		{context.Background(), "/pkg.Svc/Critical"},
+		{critical, "/pkg.Svc/Get"},
+	} {
+		go func(ctx context.Context, method string) {
+			done <- call(ctx, method, blocking)
+		}(c.ctx, c.method)
+		<-started
+	}
+
+	close(release)
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, <-done)
+	}
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(concurrencyInflight.WithLabelValues("shed")))
+}
+
+func TestConcurrencyPriorityHeaderIsOptIn(t *testing.T) {
+	critical := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(PriorityHeader, "critical"))
+
+	l := newConcurrencyLimiter("untrusted", ConcurrencyConfig{MaxLimit: 1})
+	assert.False(t, l.isCritical(critical, "/pkg.Svc/Get"))
+	assert.True(t, l.isCritical(context.Background(), "/grpc.health.v1.Health/Check"))
+
+	l = newConcurrencyLimiter("trusted", ConcurrencyConfig{MaxLimit: 1, TrustPriorityHeader: true})
+	assert.True(t, l.isCritical(critical, "/pkg.Svc/Get"))
+	assert.False(t, l.isCritical(context.Background(), "/pkg.Svc/Get"))
+}
+
+func TestConcurrencyLimiterAdapts(t *testing.T) {
+	l := newConcurrencyLimiter("adapt", ConcurrencyConfig{
+		MaxLimit:      10,
+		MinLimit:      2,
+		LatencyTarget: 50 * time.Millisecond,
+	})
+
+	// Slow calls back the limit off down to the minimum
+	for i := 0; i < 50; i++ {
+		assert.True(t, l.acquire(context.Background(), "/pkg.Svc/Get"))
+		l.release(time.Second, nil, true)
+	}
+	assert.Equal(t, 2.0, l.limit)
+	assert.Equal(t, 2.0, testutil.ToFloat64(concurrencyLimit.WithLabelValues("adapt")))
+
+	// Running out of time backs off too
+	l.limit = 5
+	assert.True(t, l.acquire(context.Background(), "/pkg.Svc/Get"))
+	l.release(time.Millisecond, status.Error(codes.DeadlineExceeded, "late"), true)
+	assert.Equal(t, 4.5, l.limit)
+
+	// Fast calls grow it again while it's in use, up to the maximum
+	for i := 0; i < 200; i++ {
+		for j := 0; j < int(l.limit); j++ {
+			assert.True(t, l.acquire(context.Background(), "/pkg.Svc/Get"))
+		}
+		for j := l.inflight; j > 0; j-- {
+			l.release(time.Millisecond, errors.New("failed fast"), true)
+		}
+	}
+	assert.Equal(t, 10.0, l.limit)
+}
+
+func TestServiceConcurrencyLimit(t *testing.T) {
+	s := testService(t, "concurrency")
+	s.Concurrency = ConcurrencyConfig{MaxLimit: 5}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	assert.Equal(t, 5.0, testutil.ToFloat64(concurrencyLimit.WithLabelValues("concurrency")))
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
@@ -0,0 +1,101 @@
+package lile
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var deadlineExceededTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lile_deadline_exceeded_total",
+	Help: "RPCs that ran past their deadline.",
+}, []string{"grpc_method"})
+
+func init() {
+	prometheus.MustRegister(deadlineExceededTotal)
+}
+
+// DeadlineConfig sets server side deadlines, so calls made without one
+// don't run forever. A deadline set by the caller is kept when it's sooner
+type DeadlineConfig struct {
+	// Default is the timeout of methods without their own, zero means no
+	// default
+	Default time.Duration
+
+	// Methods maps full method names, e.g. /pkg.Service/Method, to their
+	// timeout. protoc-gen-lile-server generates them from lile.timeout
+	// options as server.MethodTimeouts
+	Methods map[string]time.Duration
+}
+
+func (c DeadlineConfig) enabled() bool {
+	return c.Default > 0 || len(c.Methods) > 0
+}
+
+func (c DeadlineConfig) timeout(method string) time.Duration {
+	if d, ok := c.Methods[method]; ok {
+		return d
+	}
+
+	return c.Default
+}
+
+// DeadlineUnaryInterceptor applies the method's timeout to the handler's
+// context, and reports calls that run past their deadline as
+// codes.DeadlineExceeded
+func DeadlineUnaryInterceptor(c DeadlineConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := c.withTimeout(ctx, info.FullMethod)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		if exceeded(ctx, info.FullMethod) {
+			return nil, status.Errorf(codes.DeadlineExceeded,
+				"lile: %s exceeded its deadline", info.FullMethod)
+		}
+
+		return resp, err
+	}
+}
+
+// DeadlineStreamInterceptor applies the method's timeout to the whole
+// stream like DeadlineUnaryInterceptor
+func DeadlineStreamInterceptor(c DeadlineConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, cancel := c.withTimeout(ss.Context(), info.FullMethod)
+		defer cancel()
+
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		if exceeded(ctx, info.FullMethod) {
+			return status.Errorf(codes.DeadlineExceeded,
+				"lile: %s exceeded its deadline", info.FullMethod)
+		}
+
+		return err
+	}
+}
+
+func (c DeadlineConfig) withTimeout(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	d := c.timeout(method)
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, d)
+}
+
+// exceeded counts the call if its context ran out of time
+func exceeded(ctx context.Context, method string) bool {
+	if ctx.Err() != context.DeadlineExceeded {
+		return false
+	}
+
+	deadlineExceededTotal.WithLabelValues(method).Inc()
+	return true
+}
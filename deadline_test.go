@@ -0,0 +1,73 @@
+package lile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeadlines(t *testing.T) {
+	cfg := DeadlineConfig{
+		Default: time.Hour,
+		Methods: map[string]time.Duration{"/pkg.Orders/Slow": 20 * time.Millisecond},
+	}
+
+	call := func(ctx context.Context, method string) (time.Time, error) {
+		var deadline time.Time
+		_, err := DeadlineUnaryInterceptor(cfg)(ctx, nil,
+			&grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				deadline, _ = ctx.Deadline()
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		return deadline, err
+	}
+
+	exceeded := testutil.ToFloat64(deadlineExceededTotal.WithLabelValues("/pkg.Orders/Slow"))
+
+	deadline, err := call(context.Background(), "/pkg.Orders/Slow")
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.WithinDuration(t, time.Now(), deadline, time.Second)
+	assert.Equal(t, exceeded+1, testutil.ToFloat64(deadlineExceededTotal.WithLabelValues("/pkg.Orders/Slow")))
+
+	// The caller's deadline wins when it's sooner than the default
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	deadline, err = call(ctx, "/pkg.Orders/Get")
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.WithinDuration(t, time.Now(), deadline, time.Second)
+}
+
+func TestDeadlineStreams(t *testing.T) {
+	cfg := DeadlineConfig{Default: 20 * time.Millisecond}
+
+	err := DeadlineStreamInterceptor(cfg)(nil, &contextServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/pkg.Orders/Watch"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			<-ss.Context().Done()
+			return nil
+		})
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestDeadlinesDisabled(t *testing.T) {
+	assert.False(t, DeadlineConfig{}.enabled())
+
+	_, err := DeadlineUnaryInterceptor(DeadlineConfig{})(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Orders/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			return nil, nil
+		})
+	assert.Nil(t, err)
+}
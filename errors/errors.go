@@ -0,0 +1,198 @@
+// Package errors provides typed errors that handlers can return, which are
+// translated to gRPC statuses with error details attached
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is an error with a gRPC code and the details sent with it
+type Error struct {
+	Code    codes.Code
+	Message string
+
+	// Details are errdetails messages, e.g. BadRequest or ResourceInfo
+	Details []proto.Message
+
+	// Err is the underlying cause, it is never sent to callers
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the status sent to callers, it lets the error be
+// returned from handlers as is
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Message)
+	if len(e.Details) == 0 {
+		return st
+	}
+
+	if ds, err := st.WithDetails(e.Details...); err == nil {
+		return ds
+	}
+
+	return st
+}
+
+// New returns an error with the code and message
+func New(c codes.Code, format string, args ...interface{}) *Error {
+	return &Error{Code: c, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns an error with the code and message, caused by err. Callers
+// only see the message
+func Wrap(err error, c codes.Code, format string, args ...interface{}) *Error {
+	return &Error{Code: c, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// NotFound is returned when a resource doesn't exist, e.g.
+// NotFound("order", id)
+func NotFound(resourceType, name string) *Error {
+	return &Error{
+		Code:    codes.NotFound,
+		Message: fmt.Sprintf("%s %q not found", resourceType, name),
+		Details: []proto.Message{&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: name,
+		}},
+	}
+}
+
+// AlreadyExists is returned when creating a resource that already exists
+func AlreadyExists(resourceType, name string) *Error {
+	return &Error{
+		Code:    codes.AlreadyExists,
+		Message: fmt.Sprintf("%s %q already exists", resourceType, name),
+		Details: []proto.Message{&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: name,
+		}},
+	}
+}
+
+// Conflict is returned when a change conflicts with a concurrent one, e.g.
+// a stale version. Callers should retry from the start of the transaction
+func Conflict(format string, args ...interface{}) *Error {
+	return New(codes.Aborted, format, args...)
+}
+
+// FieldViolation describes an invalid field of a request
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// InvalidArgument is returned for invalid requests, listing each invalid
+// field in a BadRequest detail
+func InvalidArgument(message string, violations ...FieldViolation) *Error {
+	e := &Error{Code: codes.InvalidArgument, Message: message}
+	if len(violations) == 0 {
+		return e
+	}
+
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	e.Details = []proto.Message{br}
+	return e
+}
+
+// FailedPrecondition is returned when the system isn't in the state the
+// call requires, e.g. deleting a non-empty directory
+func FailedPrecondition(format string, args ...interface{}) *Error {
+	return New(codes.FailedPrecondition, format, args...)
+}
+
+// PermissionDenied is returned when the caller may not make the call
+func PermissionDenied(format string, args ...interface{}) *Error {
+	return New(codes.PermissionDenied, format, args...)
+}
+
+// Unauthenticated is returned when the caller's credentials are missing or
+// invalid
+func Unauthenticated(format string, args ...interface{}) *Error {
+	return New(codes.Unauthenticated, format, args...)
+}
+
+// Unavailable is returned when the call can be retried, after retryAfter
+// if it isn't zero
+func Unavailable(retryAfter time.Duration, format string, args ...interface{}) *Error {
+	e := New(codes.Unavailable, format, args...)
+	if retryAfter > 0 {
+		e.Details = []proto.Message{&errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(retryAfter),
+		}}
+	}
+
+	return e
+}
+
+// Unimplemented is returned by methods that aren't implemented yet
+func Unimplemented(format string, args ...interface{}) *Error {
+	return New(codes.Unimplemented, format, args...)
+}
+
+// Internal is returned for server faults, the cause is kept for logging
+// but callers only see the message
+func Internal(err error, format string, args ...interface{}) *Error {
+	return Wrap(err, codes.Internal, format, args...)
+}
+
+// Status translates any error to the status sent to callers. Errors and
+// statuses are found even when wrapped, and wrapped context errors become
+// codes.Canceled or codes.DeadlineExceeded. Anything else is
+// codes.Unknown, as gRPC would report it
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var se interface{ GRPCStatus() *status.Status }
+	if stderrors.As(err, &se) {
+		return se.GRPCStatus()
+	}
+
+	switch {
+	case stderrors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.New(codes.Unknown, err.Error())
+}
+
+// Code returns the code of the error as it would be sent to callers
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	return Status(err).Code()
+}
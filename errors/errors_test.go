@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code codes.Code
+	}{
+		"not found":        {NotFound("order", "42"), codes.NotFound},
+		"conflict":         {Conflict("order %s was modified", "42"), codes.Aborted},
+		"wrapped":          {fmt.Errorf("loading: %w", PermissionDenied("no")), codes.PermissionDenied},
+		"status":           {status.Error(codes.OutOfRange, "past the end"), codes.OutOfRange},
+		"wrapped status":   {fmt.Errorf("paging: %w", status.Error(codes.OutOfRange, "past the end")), codes.OutOfRange},
+		"canceled":         {fmt.Errorf("query: %w", context.Canceled), codes.Canceled},
+		"deadline":         {fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		"plain":            {stderrors.New("boom"), codes.Unknown},
+		"unimplemented":    {Unimplemented("soon"), codes.Unimplemented},
+		"internal":         {Internal(stderrors.New("disk full"), "couldn't save"), codes.Internal},
+		"failed condition": {FailedPrecondition("not empty"), codes.FailedPrecondition},
+	}
+
+	for name, c := range cases {
+		assert.Equal(t, c.code, Code(c.err), name)
+	}
+
+	assert.Equal(t, codes.OK, Code(nil))
+	assert.Nil(t, Status(nil))
+}
+
+func TestDetails(t *testing.T) {
+	st := Status(NotFound("order", "42"))
+	assert.Equal(t, `order "42" not found`, st.Message())
+	assert.Equal(t, &errdetails.ResourceInfo{ResourceType: "order", ResourceName: "42"}, st.Details()[0])
+
+	st = Status(InvalidArgument("invalid order",
+		FieldViolation{Field: "quantity", Description: "must be positive"}))
+	br := st.Details()[0].(*errdetails.BadRequest)
+	assert.Equal(t, "quantity", br.FieldViolations[0].Field)
+
+	st = Status(Unavailable(time.Second, "warming up"))
+	assert.Equal(t, int64(1), st.Details()[0].(*errdetails.RetryInfo).RetryDelay.Seconds)
+
+	// The cause is kept for logging but not sent
+	err := Internal(stderrors.New("disk full"), "couldn't save")
+	assert.Equal(t, "couldn't save", Status(err).Message())
+	assert.Equal(t, "couldn't save: disk full", err.Error())
+	assert.EqualError(t, stderrors.Unwrap(err), "disk full")
+}
+
+func TestInterceptors(t *testing.T) {
+	_, err := UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("fetching: %w", context.DeadlineExceeded)
+		})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	err = StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			return NotFound("order", "42")
+		})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Len(t, st.Details(), 1)
+}
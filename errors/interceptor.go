@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor translates errors returned by unary handlers to
+// statuses, see Status
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, Status(err).Err()
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor translates errors returned by stream handlers to
+// statuses, see Status
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return Status(err).Err()
+		}
+
+		return nil
+	}
+}
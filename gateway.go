@@ -0,0 +1,635 @@
+package lile
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// maxGatewayBody is the largest request body the gateway reads, it matches
+// gRPC's default maximum message size
+const maxGatewayBody = 4 << 20
+
+// GatewayRoute maps a HTTP method and path template to a unary gRPC method.
+// Routes are generated by protoc-gen-lile-server from google.api.http
+// annotations
+type GatewayRoute struct {
+	// HTTP method, e.g. GET
+	Method string
+	// Path template, e.g. /v1/accounts/{id}
+	Pattern string
+	// Body is the request field the HTTP body maps to, "*" for the whole
+	// request or empty when the request has no body
+	Body string
+	// Full gRPC method name, e.g. /accounts.Accounts/Get
+	GRPCMethod string
+
+	NewRequest  func() proto.Message
+	NewResponse func() proto.Message
+}
+
+// Gateway transcodes HTTP/JSON requests to gRPC calls on a connection
+type Gateway struct {
+	// Logger receives errors writing responses, it defaults to the
+	// standard logrus logger
+	Logger Logger
+
+	conn   *grpc.ClientConn
+	routes []gatewayRoute
+
+	marshaler   *jsonpb.Marshaler
+	unmarshaler *jsonpb.Unmarshaler
+}
+
+type gatewayRoute struct {
+	GatewayRoute
+	template *pathTemplate
+}
+
+// NewGateway creates a HTTP handler that transcodes requests for the routes
+// into gRPC calls on the connection
+func NewGateway(conn *grpc.ClientConn, routes []GatewayRoute) (*Gateway, error) {
+	g := &Gateway{
+		conn:        conn,
+		marshaler:   &jsonpb.Marshaler{OrigName: true},
+		unmarshaler: &jsonpb.Unmarshaler{AllowUnknownFields: true},
+	}
+
+	for _, r := range routes {
+		t, err := parsePathTemplate(r.Pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		g.routes = append(g.routes, gatewayRoute{GatewayRoute: r, template: t})
+	}
+
+	return g, nil
+}
+
+// ServeHTTP transcodes the request to a gRPC call and writes the response
+// or status as JSON
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, params, ok := g.lookup(r)
+	if !ok {
+		g.writeError(w, status.Error(codes.NotFound, "no route for "+r.Method+" "+r.URL.Path))
+		return
+	}
+
+	g.handle(w, r, route, params)
+}
+
+// Handles returns true if a route matches the request
+func (g *Gateway) Handles(r *http.Request) bool {
+	_, _, ok := g.lookup(r)
+	return ok
+}
+
+func (g *Gateway) log() Logger {
+	if g.Logger != nil {
+		return g.Logger
+	}
+
+	return defaultLogger
+}
+
+func (g *Gateway) lookup(r *http.Request) (gatewayRoute, map[string]string, bool) {
+	for _, route := range g.routes {
+		if route.Method != r.Method {
+			continue
+		}
+
+		if params, ok := route.template.match(r.URL.Path); ok {
+			return route, params, true
+		}
+	}
+
+	return gatewayRoute{}, nil, false
+}
+
+func (g *Gateway) handle(w http.ResponseWriter, r *http.Request, route gatewayRoute, params map[string]string) {
+	req := route.NewRequest()
+	r.Body = http.MaxBytesReader(w, r.Body, maxGatewayBody)
+	if err := g.decodeRequest(r, route, params, req); err != nil {
+		g.writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	ctx := metadata.NewOutgoingContext(r.Context(), gatewayMetadata(r))
+	res := route.NewResponse()
+	if err := g.conn.Invoke(ctx, route.GRPCMethod, req, res); err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := g.marshaler.Marshal(w, res); err != nil {
+		g.log().Error("lile: gateway couldn't marshal response", "error", err)
+	}
+}
+
+// decodeRequest builds the JSON form of the request from the body, path
+// parameters and query string and unmarshals it into the request message
+func (g *Gateway) decodeRequest(r *http.Request, route gatewayRoute, params map[string]string, req proto.Message) error {
+	fields := map[string]interface{}{}
+
+	if route.Body != "" {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+
+		if len(bytes.TrimSpace(b)) > 0 {
+			// Numbers are kept as written, so 64 bit integers don't lose
+			// precision as floats
+			d := json.NewDecoder(bytes.NewReader(b))
+			d.UseNumber()
+
+			var body interface{}
+			if err := d.Decode(&body); err != nil {
+				return err
+			}
+
+			if route.Body == "*" {
+				m, ok := body.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("request body must be a JSON object")
+				}
+
+				fields = m
+			} else {
+				setField(fields, strings.Split(route.Body, "."), body)
+			}
+		}
+	}
+
+	msgType := reflect.TypeOf(req)
+	for k, v := range params {
+		path := strings.Split(k, ".")
+		setField(fields, path, coerceField(msgType, path, v))
+	}
+
+	// Query parameters only map to fields not already bound by the body
+	// or path
+	if route.Body != "*" {
+		for k, vs := range r.URL.Query() {
+			path := strings.Split(k, ".")
+			if hasField(fields, path) {
+				continue
+			}
+
+			if len(vs) == 1 {
+				setField(fields, path, coerceField(msgType, path, vs[0]))
+				continue
+			}
+
+			list := make([]interface{}, len(vs))
+			for i, v := range vs {
+				list[i] = coerceField(msgType, path, v)
+			}
+
+			setField(fields, path, list)
+		}
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	return g.unmarshaler.Unmarshal(bytes.NewReader(b), req)
+}
+
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatusFromCode(st.Code()))
+	if err := g.marshaler.Marshal(w, st.Proto()); err != nil {
+		g.log().Error("lile: gateway couldn't marshal error", "error", err)
+	}
+}
+
+// gatewayMetadata forwards the Authorization header, X- headers and headers
+// prefixed with Grpc-Metadata- to the gRPC call. X-Forwarded-For is set to
+// the client's address, rather than forwarding whatever the client sent
+func gatewayMetadata(r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	for k, vs := range r.Header {
+		key := strings.ToLower(k)
+		switch {
+		case key == "x-forwarded-for", key == "grpc-metadata-x-forwarded-for":
+			// Set from the connection below
+		case key == "authorization", strings.HasPrefix(key, "x-"):
+			md.Append(key, vs...)
+		case strings.HasPrefix(key, "grpc-metadata-"):
+			md.Append(strings.TrimPrefix(key, "grpc-metadata-"), vs...)
+		}
+	}
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md.Append("x-forwarded-for", host)
+	}
+
+	return md
+}
+
+// setField sets a value in a nested JSON object following the field path
+func setField(m map[string]interface{}, path []string, v interface{}) {
+	for _, p := range path[:len(path)-1] {
+		next, ok := m[p].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[p] = next
+		}
+
+		m = next
+	}
+
+	m[path[len(path)-1]] = v
+}
+
+// hasField returns true if a value is set at the path, or the path is
+// inside a value that isn't an object
+func hasField(m map[string]interface{}, path []string) bool {
+	for _, p := range path[:len(path)-1] {
+		v, ok := m[p]
+		if !ok {
+			return false
+		}
+
+		next, ok := v.(map[string]interface{})
+		if !ok {
+			return true
+		}
+
+		m = next
+	}
+
+	_, ok := m[path[len(path)-1]]
+	return ok
+}
+
+// coerceField converts string values from paths and query strings to bools
+// when the target field is a bool. jsonpb already accepts quoted numbers
+func coerceField(t reflect.Type, path []string, v string) interface{} {
+	for _, name := range path {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return v
+		}
+
+		field, ok := protoField(t, name)
+		if !ok {
+			return v
+		}
+
+		t = field.Type
+	}
+
+	if t.Kind() == reflect.Bool {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+
+	return v
+}
+
+func protoField(t reflect.Type, name string) (reflect.StructField, bool) {
+	props := proto.GetProperties(t)
+	for _, p := range props.Prop {
+		if p.OrigName == name || p.JSONName == name {
+			return t.FieldByName(p.Name)
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// HTTPStatusFromCode maps a gRPC status code to the equivalent HTTP status
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
+
+// pathTemplate is a parsed google.api.http path template such as
+// /v1/{name=shelves/*/books/*}:publish
+type pathTemplate struct {
+	segments []templateSegment
+	verb     string
+}
+
+type templateSegment struct {
+	// literal matches a single path segment exactly, "*" matches any
+	// segment and "**" matches the rest of the path
+	literal string
+	// field is set for variables, which capture the segments of pattern
+	field   string
+	pattern []string
+}
+
+func parsePathTemplate(tmpl string) (*pathTemplate, error) {
+	if !strings.HasPrefix(tmpl, "/") {
+		return nil, fmt.Errorf("lile: path template %q must start with /", tmpl)
+	}
+
+	t := &pathTemplate{}
+	rest := tmpl[1:]
+
+	// A verb follows the last colon outside of a variable
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "}") {
+		t.verb = rest[i+1:]
+		rest = rest[:i]
+	}
+
+	for len(rest) > 0 {
+		if rest[0] == '{' {
+			end := strings.Index(rest, "}")
+			if end == -1 {
+				return nil, fmt.Errorf("lile: unterminated variable in path template %q", tmpl)
+			}
+
+			seg := templateSegment{field: rest[1:end], pattern: []string{"*"}}
+			if i := strings.Index(seg.field, "="); i != -1 {
+				seg.pattern = strings.Split(seg.field[i+1:], "/")
+				seg.field = seg.field[:i]
+			}
+
+			t.segments = append(t.segments, seg)
+			rest = strings.TrimPrefix(rest[end+1:], "/")
+			continue
+		}
+
+		end := strings.Index(rest, "/")
+		if end == -1 {
+			end = len(rest)
+		}
+
+		t.segments = append(t.segments, templateSegment{literal: rest[:end]})
+		rest = strings.TrimPrefix(rest[end:], "/")
+	}
+
+	return t, nil
+}
+
+// match returns the captured variables if the path matches the template
+func (t *pathTemplate) match(path string) (map[string]string, bool) {
+	path = strings.TrimPrefix(path, "/")
+	if t.verb != "" {
+		if !strings.HasSuffix(path, ":"+t.verb) {
+			return nil, false
+		}
+
+		path = strings.TrimSuffix(path, ":"+t.verb)
+	}
+
+	parts := strings.Split(path, "/")
+	params := map[string]string{}
+
+	for _, seg := range t.segments {
+		if seg.field == "" {
+			n, ok := matchSegments([]string{seg.literal}, parts)
+			if !ok {
+				return nil, false
+			}
+
+			parts = parts[n:]
+			continue
+		}
+
+		n, ok := matchSegments(seg.pattern, parts)
+		if !ok {
+			return nil, false
+		}
+
+		params[seg.field] = strings.Join(parts[:n], "/")
+		parts = parts[n:]
+	}
+
+	return params, len(parts) == 0
+}
+
+// matchSegments returns how many path parts the pattern consumed
+func matchSegments(pattern []string, parts []string) (int, bool) {
+	for i, p := range pattern {
+		if p == "**" {
+			return len(parts), true
+		}
+
+		if i >= len(parts) || parts[i] == "" {
+			return 0, false
+		}
+
+		if p != "*" && p != parts[i] {
+			return 0, false
+		}
+	}
+
+	return len(pattern), true
+}
+
+// gatewayBufferSize is the buffer for each direction of the gateway's
+// in-memory connections to the gRPC server
+const gatewayBufferSize = 256 << 10
+
+// gatewayListener accepts the gateway's in-memory connections to the gRPC
+// server. They never leave the process, so they skip the server's TLS
+type gatewayListener struct {
+	*bufconn.Listener
+}
+
+func (l gatewayListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return gatewayPeer{c}, nil
+}
+
+// gatewayPeer is a connection from the gateway
+type gatewayPeer struct {
+	net.Conn
+}
+
+func (gatewayPeer) RemoteAddr() net.Addr { return gatewayAddr{} }
+
+// gatewayAddr is the peer address of calls from the gateway
+type gatewayAddr struct{}
+
+func (gatewayAddr) Network() string { return "gateway" }
+func (gatewayAddr) String() string  { return "gateway" }
+
+// peerAddr returns the caller's address. Calls from the gateway carry the
+// HTTP client's address in x-forwarded-for, which is only trusted when the
+// call came over the gateway's own connection
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	if _, ok := p.Addr.(gatewayAddr); ok {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("x-forwarded-for"); len(v) > 0 {
+			return v[len(v)-1]
+		}
+	}
+
+	return p.Addr.String()
+}
+
+// gatewayCredentials hands the gateway's connections to the server without
+// a handshake, every other connection uses the wrapped credentials
+type gatewayCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c gatewayCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := conn.(gatewayPeer); ok {
+		return conn, nil, nil
+	}
+
+	return c.TransportCredentials.ServerHandshake(conn)
+}
+
+func (c gatewayCredentials) Clone() credentials.TransportCredentials {
+	return gatewayCredentials{c.TransportCredentials.Clone()}
+}
+
+// gatewayConn serves the gRPC server on an in-memory listener and dials it
+// for the gateway to forward requests to. Requests are forwarded in the
+// process, rather than through the service's own listener, so the gateway
+// doesn't need a client certificate the server trusts
+func (s *Service) gatewayConn(gs *grpc.Server) (*grpc.ClientConn, error) {
+	l := bufconn.Listen(gatewayBufferSize)
+	conn, err := grpc.Dial("gateway",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return l.Dial()
+		}),
+	)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	go func() {
+		if err := gs.Serve(gatewayListener{l}); err != nil && err != grpc.ErrServerStopped {
+			s.log().Error("lile: gateway gRPC server failed", "error", err)
+		}
+	}()
+
+	return conn, nil
+}
+
+// startGateway creates the HTTP/JSON gateway for the GatewayRoutes. It is
+// mounted on the multiplexed listener in single port mode, otherwise it is
+// served on GatewayConfig
+func (s *Service) startGateway(gs *grpc.Server) error {
+	if len(s.GatewayRoutes) == 0 {
+		return nil
+	}
+
+	// Routes and the gateway's TLS config are checked before anything is
+	// started, so a bad config doesn't leave a listener or server behind
+	gw, err := NewGateway(nil, s.GatewayRoutes)
+	if err != nil {
+		return err
+	}
+	gw.Logger = s.log()
+
+	var cfg *tls.Config
+	if !s.SinglePort && s.GatewayConfig.TLSEnabled() {
+		cfg, err = s.GatewayConfig.serverTLSConfig(s.log())
+		if err != nil {
+			return err
+		}
+
+		cfg.NextProtos = []string{"h2", "http/1.1"}
+	}
+
+	var l net.Listener
+	if !s.SinglePort {
+		l, err = s.GatewayConfig.Listen("gateway")
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := s.gatewayConn(gs)
+	if err != nil {
+		if l != nil {
+			l.Close()
+		}
+		return err
+	}
+
+	gw.conn = conn
+	s.gatewayConnection = conn
+
+	if s.SinglePort {
+		s.gateway = gw
+		return nil
+	}
+
+	if cfg != nil {
+		l = tls.NewListener(l, cfg)
+	}
+
+	s.GatewayServer = &http.Server{Handler: gw}
+	s.log().Info("lile: serving HTTP/JSON gateway", "addr", l.Addr().String())
+	go func() {
+		if err := s.GatewayServer.Serve(l); err != nil && err != http.ErrServerClosed {
+			s.log().Error("lile: gateway http server failed", "error", err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package lile
+
+import (
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+var healthRoutes = []GatewayRoute{
+	{
+		Method:      "GET",
+		Pattern:     "/v1/health/{service=**}",
+		GRPCMethod:  "/grpc.health.v1.Health/Check",
+		NewRequest:  func() proto.Message { return &healthpb.HealthCheckRequest{} },
+		NewResponse: func() proto.Message { return &healthpb.HealthCheckResponse{} },
+	},
+	{
+		Method:      "POST",
+		Pattern:     "/v1/health:check",
+		Body:        "*",
+		GRPCMethod:  "/grpc.health.v1.Health/Check",
+		NewRequest:  func() proto.Message { return &healthpb.HealthCheckRequest{} },
+		NewResponse: func() proto.Message { return &healthpb.HealthCheckResponse{} },
+	},
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		path     string
+		params   map[string]string
+		ok       bool
+	}{
+		{"/v1/accounts/{id}", "/v1/accounts/123", map[string]string{"id": "123"}, true},
+		{"/v1/accounts/{id}", "/v1/accounts/123/extra", nil, false},
+		{"/v1/accounts/{id}", "/v1/accounts/", nil, false},
+		{"/v1/{name=shelves/*/books/*}", "/v1/shelves/1/books/2",
+			map[string]string{"name": "shelves/1/books/2"}, true},
+		{"/v1/{name=shelves/*}:publish", "/v1/shelves/1:publish",
+			map[string]string{"name": "shelves/1"}, true},
+		{"/v1/{name=shelves/*}:publish", "/v1/shelves/1", nil, false},
+		{"/v1/files/{path=**}", "/v1/files/a/b/c", map[string]string{"path": "a/b/c"}, true},
+	}
+
+	for _, tt := range tests {
+		tmpl, err := parsePathTemplate(tt.template)
+		assert.Nil(t, err)
+
+		params, ok := tmpl.match(tt.path)
+		assert.Equal(t, tt.ok, ok, tt.template+" "+tt.path)
+		if tt.ok {
+			assert.Equal(t, tt.params, params)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	s := testService(t, "gateway")
+	s.GatewayConfig = ServerConfig{Host: "127.0.0.1", Port: freePort(t)}
+	s.GatewayRoutes = healthRoutes
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	base := "http://" + s.GatewayConfig.Address()
+	code, body := httpGet(t, base+"/v1/health/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status":"SERVING"}`, body)
+
+	code, body = httpGet(t, base+"/v1/health/missing.Service")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, body, "unknown service")
+
+	res, err := http.Post(base+"/v1/health:check", "application/json",
+		strings.NewReader(`{"service":"grpc.health.v1.Health"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res.Body.Close()
+
+	// Oversized bodies are rejected before they're read into memory
+	res, err = http.Post(base+"/v1/health:check", "application/json",
+		strings.NewReader(`{"service":"`+strings.Repeat("a", maxGatewayBody)+`"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	res.Body.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestGatewayMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// The server's certificate can't be used as a client certificate, and
+	// client certificates come from a different CA
+	os.Mkdir(filepath.Join(dir, "server-ca"), 0700)
+	os.Mkdir(filepath.Join(dir, "client-ca"), 0700)
+	serverCA, serverCAKey := writeCert(t, filepath.Join(dir, "server-ca"), "server-ca")
+	clientCA, _ := writeCert(t, filepath.Join(dir, "client-ca"), "client-ca")
+
+	s := testService(t, "gateway-mtls")
+	s.Config.TLSCert, s.Config.TLSKey = writeLeafCert(t, dir, "server", serverCA, serverCAKey, x509.ExtKeyUsageServerAuth)
+	s.Config.TLSClientCA = clientCA
+	s.GatewayConfig = ServerConfig{Host: "127.0.0.1", Port: freePort(t)}
+	s.GatewayRoutes = healthRoutes
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	code, body := httpGet(t, "http://"+s.GatewayConfig.Address()+"/v1/health/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status":"SERVING"}`, body)
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestGatewayBadTLSConfig(t *testing.T) {
+	s := testService(t, "gateway-bad-tls")
+	s.GatewayConfig = ServerConfig{Host: "127.0.0.1", Port: freePort(t),
+		TLSCert: "/nonexistent/tls.crt", TLSKey: "/nonexistent/tls.key"}
+	s.GatewayRoutes = healthRoutes
+
+	assert.NotNil(t, s.Run())
+	assert.Nil(t, s.gatewayConnection)
+
+	// The gateway's port is left free
+	l, err := net.Listen("tcp", s.GatewayConfig.Address())
+	assert.Nil(t, err)
+	if l != nil {
+		l.Close()
+	}
+}
+
+func TestGatewayForwardedFor(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v1/health/", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("X-Forwarded-For", "10.0.0.1")
+	r.Header.Set("Grpc-Metadata-X-Forwarded-For", "10.0.0.2")
+
+	// The client's own header is replaced by its address
+	md := gatewayMetadata(r)
+	assert.Equal(t, []string{"192.0.2.1"}, md.Get("x-forwarded-for"))
+
+	ctx := metadata.NewIncomingContext(
+		peer.NewContext(context.Background(), &peer.Peer{Addr: gatewayAddr{}}), md)
+	assert.Equal(t, "192.0.2.1", peerAddr(ctx))
+
+	// It's only trusted on calls from the gateway
+	ctx = metadata.NewIncomingContext(peerContext("10.0.0.3"), md)
+	assert.Equal(t, "10.0.0.3:1234", peerAddr(ctx))
+}
+
+func TestGatewayRateLimit(t *testing.T) {
+	s := testService(t, "gateway-rate-limit")
+	s.GatewayConfig = ServerConfig{Host: "127.0.0.1", Port: freePort(t)}
+	s.GatewayRoutes = healthRoutes
+	s.RateLimiter.Limits["*"] = RateLimit{Rate: 0.001, Burst: 1}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	get := func(forwardedFor string) int {
+		req, _ := http.NewRequest("GET", "http://"+s.GatewayConfig.Address()+"/v1/health/", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	// Callers are limited by their own address, however they set the
+	// header
+	assert.Equal(t, http.StatusOK, get("10.0.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, get("10.0.0.2"))
+	assert.Contains(t, s.RateLimiter.buckets, rateBucketKey{method: "/grpc.health.v1.Health/Check", caller: "127.0.0.1"})
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestGatewayQueryDoesNotOverrideBody(t *testing.T) {
+	g, err := NewGateway(nil, nil)
+	assert.Nil(t, err)
+
+	req := &healthpb.HealthCheckRequest{}
+	r := httptest.NewRequest("POST", "/v1/health?service=query", strings.NewReader(`"body"`))
+	assert.Nil(t, g.decodeRequest(r, gatewayRoute{GatewayRoute: GatewayRoute{Body: "service"}}, nil, req))
+	assert.Equal(t, "body", req.Service)
+
+	req = &healthpb.HealthCheckRequest{}
+	r = httptest.NewRequest("GET", "/v1/health/path?service=query", nil)
+	assert.Nil(t, g.decodeRequest(r, gatewayRoute{}, map[string]string{"service": "path"}, req))
+	assert.Equal(t, "path", req.Service)
+
+	req = &healthpb.HealthCheckRequest{}
+	r = httptest.NewRequest("GET", "/v1/health?service=query", nil)
+	assert.Nil(t, g.decodeRequest(r, gatewayRoute{}, nil, req))
+	assert.Equal(t, "query", req.Service)
+}
+
+func TestGatewayInt64Body(t *testing.T) {
+	g, err := NewGateway(nil, nil)
+	assert.Nil(t, err)
+
+	// 2^53 + 1 can't be represented as a float64
+	req := &descriptor.UninterpretedOption{}
+	r := httptest.NewRequest("POST", "/v1/options", strings.NewReader(`{"negative_int_value": -9007199254740993}`))
+	assert.Nil(t, g.decodeRequest(r, gatewayRoute{GatewayRoute: GatewayRoute{Body: "*"}}, nil, req))
+	assert.Equal(t, int64(-9007199254740993), req.GetNegativeIntValue())
+
+	req = &descriptor.UninterpretedOption{}
+	r = httptest.NewRequest("POST", "/v1/options", strings.NewReader(`9007199254740993`))
+	assert.Nil(t, g.decodeRequest(r, gatewayRoute{GatewayRoute: GatewayRoute{Body: "positive_int_value"}}, nil, req))
+	assert.Equal(t, uint64(9007199254740993), req.GetPositiveIntValue())
+}
@@ -0,0 +1,117 @@
+package lile
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthChecker is a named probe of a dependency that the service relies on,
+// such as a database or a downstream service
+type HealthChecker struct {
+	// Name identifies the probe in logs
+	Name string
+
+	// Services lists the fully qualified gRPC services (e.g. "pkg.Accounts")
+	// that depend on this probe. When empty every service depends on it
+	Services []string
+
+	// Check returns an error when the dependency is unhealthy
+	Check func(ctx context.Context) error
+}
+
+// startHealthChecks runs the health checkers once and then every
+// HealthCheckInterval until the service shuts down
+func (s *Service) startHealthChecks() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.mu.Lock()
+	if s.state != StateServing {
+		s.mu.Unlock()
+		cancel()
+		return
+	}
+	s.stopHealthChecks = cancel
+	s.mu.Unlock()
+
+	s.updateHealth(ctx)
+	if len(s.HealthCheckers) == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.HealthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.updateHealth(ctx)
+			}
+		}
+	}()
+}
+
+// updateHealth runs every health checker and sets the serving status of
+// the service as a whole and of each registered gRPC service
+func (s *Service) updateHealth(ctx context.Context) {
+	overall := healthpb.HealthCheckResponse_SERVING
+	allFailed := false
+	failed := map[string]bool{}
+
+	for _, hc := range s.HealthCheckers {
+		cctx, cancel := context.WithTimeout(ctx, s.HealthCheckInterval)
+		err := hc.Check(cctx)
+		cancel()
+
+		if err == nil {
+			continue
+		}
+
+		s.log().Warn("lile: health check failed", "check", hc.Name, "error", err)
+		overall = healthpb.HealthCheckResponse_NOT_SERVING
+		if len(hc.Services) == 0 {
+			allFailed = true
+		}
+
+		for _, svc := range hc.Services {
+			failed[svc] = true
+		}
+	}
+
+	s.HealthServer.SetServingStatus("", overall)
+	for name := range s.GRPCServer.GetServiceInfo() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if allFailed || failed[name] {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		s.HealthServer.SetServingStatus(name, status)
+	}
+}
+
+// shutdownHealth marks every service as NOT_SERVING so that load balancers
+// stop routing new requests while the server drains
+func (s *Service) shutdownHealth() {
+	s.mu.Lock()
+	stop := s.stopHealthChecks
+	s.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+
+	if s.HealthServer != nil {
+		s.HealthServer.Shutdown()
+	}
+}
+
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
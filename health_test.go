@@ -0,0 +1,67 @@
+package lile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func healthStatus(t *testing.T, s *Service, name string) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(),
+		grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx,
+		&healthpb.HealthCheckRequest{Service: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res.Status
+}
+
+func TestHealthServing(t *testing.T) {
+	s := testService(t, "health")
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, s, ""))
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestHealthCheckerFailure(t *testing.T) {
+	s := testService(t, "health")
+	s.HealthCheckers = []HealthChecker{
+		{
+			Name:     "db",
+			Services: []string{"pkg.Accounts"},
+			Check: func(ctx context.Context) error {
+				return errors.New("connection refused")
+			},
+		},
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, healthStatus(t, s, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING,
+		healthStatus(t, s, "grpc.health.v1.Health"))
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
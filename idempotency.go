@@ -0,0 +1,433 @@
+package lile
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// IdempotencyHeader is the metadata header clients set to make retries of
+// a call safe, calls with the same key get the first call's result
+const IdempotencyHeader = "idempotency-key"
+
+// maxIdempotencyKey is the longest idempotency key accepted
+const maxIdempotencyKey = 256
+
+var idempotentReplaysTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lile_idempotent_replays_total",
+	Help: "RPCs answered with the stored result of an earlier call with the same idempotency key.",
+}, []string{"grpc_method"})
+
+func init() {
+	prometheus.MustRegister(idempotentReplaysTotal)
+}
+
+// retryableCodes are failures that aren't stored, so a retry runs the call
+// again rather than replaying the failure
+var retryableCodes = map[codes.Code]bool{
+	codes.Canceled:          true,
+	codes.DeadlineExceeded:  true,
+	codes.ResourceExhausted: true,
+	codes.Aborted:           true,
+	codes.Unavailable:       true,
+}
+
+// IdempotentResult is the stored result of a call, or a reservation for a
+// call that is still running
+type IdempotentResult struct {
+	// RequestHash is the SHA-256 of the marshalled request, a key reused
+	// with a different request is rejected
+	RequestHash []byte
+
+	// Pending marks a reservation, the call hasn't finished yet
+	Pending bool
+
+	// ResponseType and Response are the name and marshalled bytes of the
+	// response message, when the call succeeded
+	ResponseType string
+	Response     []byte
+
+	// Status is the marshalled google.rpc.Status, when the call failed
+	Status []byte
+}
+
+// IdempotencyStore keeps call results for replay. Implementations must be
+// safe for concurrent use, and when shared between instances Reserve must
+// be atomic across all of them, e.g. SET NX in Redis
+type IdempotencyStore interface {
+	// Reserve stores r for the key until ttl has passed, unless the key
+	// already has an entry. It returns the existing entry, or nil when r
+	// was stored
+	Reserve(ctx context.Context, key string, r *IdempotentResult, ttl time.Duration) (*IdempotentResult, error)
+
+	// Put stores the result for the key until ttl has passed, replacing
+	// its reservation
+	Put(ctx context.Context, key string, r *IdempotentResult, ttl time.Duration) error
+
+	// Delete removes the key's entry, so the call can run again
+	Delete(ctx context.Context, key string) error
+}
+
+// IdempotencyConfig configures replay of unary calls that carry an
+// IdempotencyHeader. Results are keyed by method, principal and key
+type IdempotencyConfig struct {
+	// Store enables idempotency keys for Methods when set
+	Store IdempotencyStore
+
+	// TTL is how long results are kept, it defaults to 24 hours
+	TTL time.Duration
+
+	// ReserveTTL is how long a running call holds its key, so the key is
+	// freed if the instance dies mid-call. It defaults to 5 minutes and
+	// should be longer than the slowest call
+	ReserveTTL time.Duration
+
+	// Methods lists the full method names that honour idempotency keys,
+	// "*" opts in every unary method. Keys are ignored until methods are
+	// listed, so replaying results is opted into for calls that are safe to
+	// replay
+	Methods []string
+}
+
+type idempotency struct {
+	store      IdempotencyStore
+	ttl        time.Duration
+	reserveTTL time.Duration
+	methods    map[string]bool
+	log        Logger
+
+	mu       sync.Mutex
+	inflight map[string]chan struct{}
+}
+
+func newIdempotency(cfg IdempotencyConfig, log Logger) *idempotency {
+	i := &idempotency{
+		store:      cfg.Store,
+		ttl:        cfg.TTL,
+		reserveTTL: cfg.ReserveTTL,
+		log:        log,
+		inflight:   map[string]chan struct{}{},
+	}
+
+	if i.ttl <= 0 {
+		i.ttl = 24 * time.Hour
+	}
+	if i.reserveTTL <= 0 {
+		i.reserveTTL = 5 * time.Minute
+	}
+
+	i.methods = map[string]bool{}
+	for _, m := range cfg.Methods {
+		i.methods[m] = true
+	}
+
+	return i
+}
+
+// UnaryInterceptor replays the stored result of calls with a known
+// idempotency key, and stores the result of the rest. The key is reserved
+// before the handler runs, so a call runs once even across instances.
+// Retries that arrive on this instance while the first call is running
+// wait for its result, those on other instances fail with codes.Aborted
+func (i *idempotency) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if !i.methods["*"] && !i.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(IdempotencyHeader)
+		if len(keys) == 0 || keys[0] == "" {
+			return handler(ctx, req)
+		}
+
+		if len(keys[0]) > maxIdempotencyKey {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"lile: %s must be at most %d bytes", IdempotencyHeader, maxIdempotencyKey)
+		}
+
+		key := idempotencyStoreKey(ctx, info.FullMethod, keys[0])
+
+		for {
+			i.mu.Lock()
+			wait, running := i.inflight[key]
+			if !running {
+				i.inflight[key] = make(chan struct{})
+			}
+			i.mu.Unlock()
+
+			if !running {
+				break
+			}
+
+			select {
+			case <-wait:
+			case <-ctx.Done():
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+
+		defer func() {
+			i.mu.Lock()
+			close(i.inflight[key])
+			delete(i.inflight, key)
+			i.mu.Unlock()
+		}()
+
+		hash := requestHash(req)
+		r, err := i.store.Reserve(ctx, key, &IdempotentResult{RequestHash: hash, Pending: true}, i.reserveTTL)
+		if err != nil {
+			// Running the call again could apply it twice
+			i.log.Error("lile: idempotency store failed", "method", info.FullMethod, "error", err)
+			return nil, status.Error(codes.Unavailable, "lile: idempotency store unavailable")
+		}
+
+		if r != nil {
+			if !bytes.Equal(r.RequestHash, hash) {
+				return nil, status.Errorf(codes.InvalidArgument,
+					"lile: %s was already used with a different request", IdempotencyHeader)
+			}
+
+			if r.Pending {
+				return nil, status.Error(codes.Aborted,
+					"lile: a call with the same idempotency key is still running")
+			}
+
+			idempotentReplaysTotal.WithLabelValues(info.FullMethod).Inc()
+			return replay(r)
+		}
+
+		finished := false
+		defer func() {
+			// The handler panicked, free the key so a retry can run
+			if !finished {
+				i.release(detachedContext{ctx}, info.FullMethod, key)
+			}
+		}()
+
+		resp, err := handler(ctx, req)
+		finished = true
+		i.save(detachedContext{ctx}, info.FullMethod, key, hash, resp, err)
+		return resp, err
+	}
+}
+
+// requestHash returns the SHA-256 of the deterministically marshalled
+// request, or nil when it isn't a message
+func requestHash(req interface{}) []byte {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(m); err != nil {
+		return nil
+	}
+
+	h := sha256.Sum256(b.Bytes())
+	return h[:]
+}
+
+// detachedContext keeps a call's values but not its cancellation, so the
+// result of a call is stored even when the caller has gone
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// IdempotencyKeyClientInterceptor gives each unary call a random
+// idempotency key unless it already has one. The key is sent with every
+// attempt of the call, so transport retries can't apply it twice
+func IdempotencyKeyClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, resp interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if len(md.Get(IdempotencyHeader)) == 0 {
+			uid, _ := uuid.NewV4()
+			ctx = metadata.AppendToOutgoingContext(ctx, IdempotencyHeader, uid.String())
+		}
+
+		return invoker(ctx, method, req, resp, cc, opts...)
+	}
+}
+
+// save stores the result of a call in place of its reservation. Failures
+// worth retrying, and results that can't be stored, free the key instead
+func (i *idempotency) save(ctx context.Context, method, key string, hash []byte, resp interface{}, err error) {
+	r := result(resp, err)
+	if r == nil {
+		i.release(ctx, method, key)
+		return
+	}
+
+	r.RequestHash = hash
+	if err := i.store.Put(ctx, key, r, i.ttl); err != nil {
+		i.log.Warn("lile: couldn't store idempotent result", "method", method, "error", err)
+	}
+}
+
+// release deletes the key's reservation, if that fails the key is freed
+// once ReserveTTL has passed
+func (i *idempotency) release(ctx context.Context, method, key string) {
+	if err := i.store.Delete(ctx, key); err != nil {
+		i.log.Warn("lile: couldn't release idempotency key", "method", method, "error", err)
+	}
+}
+
+// result marshals the outcome of a call, or returns nil when it shouldn't
+// be stored
+func result(resp interface{}, err error) *IdempotentResult {
+	if err != nil {
+		st := status.Convert(err)
+		if retryableCodes[st.Code()] {
+			return nil
+		}
+
+		b, merr := proto.Marshal(st.Proto())
+		if merr != nil {
+			return nil
+		}
+
+		return &IdempotentResult{Status: b}
+	}
+
+	m, ok := resp.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	b, merr := proto.Marshal(m)
+	if merr != nil {
+		return nil
+	}
+
+	return &IdempotentResult{ResponseType: proto.MessageName(m), Response: b}
+}
+
+// replay returns a stored result as the call's response or error
+func replay(r *IdempotentResult) (interface{}, error) {
+	if r.Status != nil {
+		st := &spb.Status{}
+		if err := proto.Unmarshal(r.Status, st); err != nil {
+			return nil, status.Errorf(codes.Internal, "lile: corrupt idempotent result: %v", err)
+		}
+
+		return nil, status.ErrorProto(st)
+	}
+
+	t := proto.MessageType(r.ResponseType)
+	if t == nil {
+		return nil, status.Errorf(codes.Internal, "lile: unknown response type %s", r.ResponseType)
+	}
+
+	m := reflect.New(t.Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(r.Response, m); err != nil {
+		return nil, status.Errorf(codes.Internal, "lile: corrupt idempotent result: %v", err)
+	}
+
+	return m, nil
+}
+
+// idempotencyStoreKey scopes a key to the method and principal, so callers
+// can't replay each other's results
+func idempotencyStoreKey(ctx context.Context, method, key string) string {
+	subject := ""
+	if p, ok := PrincipalFromContext(ctx); ok {
+		subject = p.Subject
+	}
+
+	return method + "\x00" + subject + "\x00" + key
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore for a single instance,
+// results are lost on restart
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	results   map[string]memoryIdempotentResult
+	lastSweep time.Time
+}
+
+type memoryIdempotentResult struct {
+	result  *IdempotentResult
+	expires time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{results: map[string]memoryIdempotentResult{}}
+}
+
+// Reserve implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, key string, r *IdempotentResult, ttl time.Duration) (*IdempotentResult, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.results[key]; ok && !now.After(existing.expires) {
+		return existing.result, nil
+	}
+
+	s.put(now, key, r, ttl)
+	return nil, nil
+}
+
+// Put implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Put(ctx context.Context, key string, r *IdempotentResult, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(time.Now(), key, r, ttl)
+	return nil
+}
+
+// Delete implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.results, key)
+	return nil
+}
+
+// put must be called with mu held
+func (s *MemoryIdempotencyStore) put(now time.Time, key string, r *IdempotentResult, ttl time.Duration) {
+	if s.results == nil {
+		s.results = map[string]memoryIdempotentResult{}
+	}
+
+	s.sweep(now)
+	s.results[key] = memoryIdempotentResult{result: r, expires: now.Add(ttl)}
+}
+
+// sweep drops expired results, it must be called with mu held
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+
+	s.lastSweep = now
+	for k, r := range s.results {
+		if now.After(r.expires) {
+			delete(s.results, k)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package lile
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func idempotencyKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyHeader, key))
+}
+
+func TestIdempotency(t *testing.T) {
+	method := "/pkg.Payments/Charge"
+	interceptor := newIdempotency(IdempotencyConfig{Store: NewMemoryIdempotencyStore(), Methods: []string{"*"}},
+		newRecordingLogger()).UnaryInterceptor()
+
+	var calls int32
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		n := atomic.AddInt32(&calls, 1)
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_ServingStatus(n)}, nil
+	}
+
+	call := func(ctx context.Context) (interface{}, error) {
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	replays := testutil.ToFloat64(idempotentReplaysTotal.WithLabelValues(method))
+
+	first, err := call(idempotencyKey("a"))
+	assert.Nil(t, err)
+
+	again, err := call(idempotencyKey("a"))
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(first.(proto.Message), again.(proto.Message)))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, replays+1, testutil.ToFloat64(idempotentReplaysTotal.WithLabelValues(method)))
+
+	// Other keys, other callers and calls without a key run again
+	call(idempotencyKey("b"))
+	call(ContextWithPrincipal(idempotencyKey("a"), &Principal{Subject: "sam"}))
+	call(context.Background())
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyFailures(t *testing.T) {
+	interceptor := newIdempotency(IdempotencyConfig{Store: NewMemoryIdempotencyStore(), Methods: []string{"*"}},
+		newRecordingLogger()).UnaryInterceptor()
+
+	var calls int32
+	call := func(key string, err error) error {
+		_, err = interceptor(idempotencyKey(key), nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				return nil, err
+			})
+		return err
+	}
+
+	// Failures are replayed, with their details
+	assert.Equal(t, codes.FailedPrecondition, status.Code(call("a", status.Error(codes.FailedPrecondition, "card declined"))))
+	err := call("a", nil)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "card declined", status.Convert(err).Message())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// but failures worth retrying aren't
+	assert.Equal(t, codes.Unavailable, status.Code(call("b", status.Error(codes.Unavailable, "try again"))))
+	assert.Nil(t, call("b", nil))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyConcurrentRetries(t *testing.T) {
+	interceptor := newIdempotency(IdempotencyConfig{Store: NewMemoryIdempotencyStore(), Methods: []string{"*"}},
+		newRecordingLogger()).UnaryInterceptor()
+
+	var calls int32
+	release := make(chan struct{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &healthpb.HealthCheckResponse{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := interceptor(idempotencyKey("a"), nil,
+				&grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"}, handler)
+			assert.Nil(t, err)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyRequestMismatch(t *testing.T) {
+	interceptor := newIdempotency(IdempotencyConfig{Store: NewMemoryIdempotencyStore(), Methods: []string{"*"}},
+		newRecordingLogger()).UnaryInterceptor()
+
+	call := func(req *healthpb.HealthCheckRequest) error {
+		_, err := interceptor(idempotencyKey("a"), req,
+			&grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &healthpb.HealthCheckResponse{}, nil
+			})
+		return err
+	}
+
+	assert.Nil(t, call(&healthpb.HealthCheckRequest{Service: "10.00"}))
+	assert.Nil(t, call(&healthpb.HealthCheckRequest{Service: "10.00"}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(call(&healthpb.HealthCheckRequest{Service: "99.00"})))
+}
+
+func TestIdempotencySharedStore(t *testing.T) {
+	// Instances sharing a store run a call once between them
+	store := NewMemoryIdempotencyStore()
+	a := newIdempotency(IdempotencyConfig{Store: store, Methods: []string{"*"}}, newRecordingLogger()).UnaryInterceptor()
+	b := newIdempotency(IdempotencyConfig{Store: store, Methods: []string{"*"}}, newRecordingLogger()).UnaryInterceptor()
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-release
+		}
+		return &healthpb.HealthCheckResponse{}, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"}
+	first := make(chan error, 1)
+	go func() {
+		_, err := a(idempotencyKey("a"), nil, info, handler)
+		first <- err
+	}()
+	<-started
+
+	_, err := b(idempotencyKey("a"), nil, info, handler)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	close(release)
+	assert.Nil(t, <-first)
+
+	_, err = b(idempotencyKey("a"), nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotencyPanicReleasesKey(t *testing.T) {
+	interceptor := newIdempotency(IdempotencyConfig{Store: NewMemoryIdempotencyStore(), Methods: []string{"*"}},
+		newRecordingLogger()).UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"}
+
+	assert.Panics(t, func() {
+		interceptor(idempotencyKey("a"), nil, info,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("boom")
+			})
+	})
+
+	_, err := interceptor(idempotencyKey("a"), nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &healthpb.HealthCheckResponse{}, nil
+		})
+	assert.Nil(t, err)
+}
+
+type failingIdempotencyStore struct{}
+
+func (failingIdempotencyStore) Reserve(ctx context.Context, key string, r *IdempotentResult, ttl time.Duration) (*IdempotentResult, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingIdempotencyStore) Put(ctx context.Context, key string, r *IdempotentResult, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingIdempotencyStore) Delete(ctx context.Context, key string) error {
+	return errors.New("connection refused")
+}
+
+func TestIdempotencyStoreErrors(t *testing.T) {
+	interceptor := newIdempotency(IdempotencyConfig{
+		Store:   failingIdempotencyStore{},
+		Methods: []string{"/pkg.Payments/Charge"},
+	}, newRecordingLogger()).UnaryInterceptor()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &healthpb.HealthCheckResponse{}, nil
+	}
+
+	_, err := interceptor(idempotencyKey("a"), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// Methods that aren't listed ignore the key
+	_, err = interceptor(idempotencyKey("a"), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/List"}, handler)
+	assert.Nil(t, err)
+
+	// As does every method until some are listed
+	interceptor = newIdempotency(IdempotencyConfig{Store: failingIdempotencyStore{}},
+		newRecordingLogger()).UnaryInterceptor()
+	_, err = interceptor(idempotencyKey("a"), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/pkg.Payments/Charge"}, handler)
+	assert.Nil(t, err)
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	s := &MemoryIdempotencyStore{}
+	ctx := context.Background()
+
+	assert.Nil(t, s.Put(ctx, "a", &IdempotentResult{ResponseType: "a"}, time.Hour))
+	assert.Nil(t, s.Put(ctx, "b", &IdempotentResult{ResponseType: "b"}, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	// Keys with an entry can't be reserved, expired ones can
+	r, err := s.Reserve(ctx, "a", &IdempotentResult{Pending: true}, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", r.ResponseType)
+
+	r, err = s.Reserve(ctx, "b", &IdempotentResult{Pending: true}, time.Hour)
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+
+	r, err = s.Reserve(ctx, "b", &IdempotentResult{}, time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, r.Pending)
+
+	assert.Nil(t, s.Delete(ctx, "b"))
+	r, err = s.Reserve(ctx, "b", &IdempotentResult{}, time.Hour)
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+}
+
+func TestIdempotencyKeyClientInterceptor(t *testing.T) {
+	var keys []string
+	invoker := func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		keys = md.Get(IdempotencyHeader)
+		return nil
+	}
+
+	interceptor := IdempotencyKeyClientInterceptor()
+
+	assert.Nil(t, interceptor(context.Background(), "/pkg.Payments/Charge", nil, nil, nil, invoker))
+	assert.Len(t, keys, 1)
+	assert.NotEmpty(t, keys[0])
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), IdempotencyHeader, "order-42")
+	assert.Nil(t, interceptor(ctx, "/pkg.Payments/Charge", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"order-42"}, keys)
+}
@@ -0,0 +1,320 @@
+package lile
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+// jwksRetryInterval limits how often an unknown key ID refetches the keys
+const jwksRetryInterval = time.Minute
+
+// JWTConfig configures a JWTAuthenticator
+type JWTConfig struct {
+	// JWKS is the http(s) URL or local file path of the JSON Web Key Set
+	// used to verify tokens
+	JWKS string
+
+	// Issuer and Audience are checked against the token's claims when set
+	Issuer   string
+	Audience string
+
+	// RolesClaim names the claim holding the principal's roles, it defaults
+	// to roles
+	RolesClaim string
+
+	// RefreshInterval is how often the key set is fetched again, it
+	// defaults to an hour. Unknown key IDs also trigger a fetch, so rotated
+	// keys are picked up straight away
+	RefreshInterval time.Duration
+
+	// Leeway allows for clock skew when checking expiry
+	Leeway time.Duration
+}
+
+// JWTAuthenticator authenticates bearer tokens from the authorization
+// header, verified against a JSON Web Key Set
+type JWTAuthenticator struct {
+	cfg    JWTConfig
+	client *http.Client
+	parser *jwt.Parser
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetched   time.Time
+	lastRetry time.Time
+}
+
+// NewJWTAuthenticator creates a JWT authenticator and loads the key set
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Hour
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{
+			"RS256", "RS384", "RS512",
+			"PS256", "PS384", "PS512",
+			"ES256", "ES384", "ES512",
+			"EdDSA",
+		}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	a := &JWTAuthenticator{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		parser: jwt.NewParser(opts...),
+	}
+
+	if err := a.refresh(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Authenticate implements Authenticator
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return nil, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(parts[1], claims, a.key); err != nil {
+		return nil, fmt.Errorf("lile: invalid token: %v", err)
+	}
+
+	p := &Principal{Claims: claims}
+	p.Subject, _ = claims.GetSubject()
+
+	switch roles := claims[a.cfg.RolesClaim].(type) {
+	case []interface{}:
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				p.Roles = append(p.Roles, s)
+			}
+		}
+	case string:
+		p.Roles = strings.Fields(roles)
+	}
+
+	return p, nil
+}
+
+// key finds the verification key for a token by its key ID
+func (a *JWTAuthenticator) key(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	a.mu.Lock()
+	key, ok := a.keys[kid]
+	stale := time.Since(a.fetched) > a.cfg.RefreshInterval
+	due := (stale || !ok) && time.Since(a.lastRetry) > jwksRetryInterval
+	if due {
+		a.lastRetry = time.Now()
+	}
+	a.mu.Unlock()
+
+	if due {
+		if err := a.refresh(); err != nil {
+			// A stale key set is better than none if the fetch fails
+			if !ok {
+				return nil, err
+			}
+		}
+
+		a.mu.Lock()
+		key, ok = a.keys[kid]
+		a.mu.Unlock()
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("lile: unknown key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (a *JWTAuthenticator) refresh() error {
+	b, err := a.fetch()
+	if err != nil {
+		return fmt.Errorf("lile: couldn't load JWKS from %s: %v", a.cfg.JWKS, err)
+	}
+
+	keys, err := parseJWKS(b)
+	if err != nil {
+		return fmt.Errorf("lile: couldn't parse JWKS from %s: %v", a.cfg.JWKS, err)
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.fetched = time.Now()
+	a.mu.Unlock()
+	return nil
+}
+
+func (a *JWTAuthenticator) fetch() ([]byte, error) {
+	if !strings.HasPrefix(a.cfg.JWKS, "http://") && !strings.HasPrefix(a.cfg.JWKS, "https://") {
+		return ioutil.ReadFile(a.cfg.JWKS)
+	}
+
+	res, err := a.client.Get(a.cfg.JWKS)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS parses the signing keys of a JSON Web Key Set by key ID. Keys
+// of unsupported types or curves are skipped
+func parseJWKS(b []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", k.Kid, err)
+		}
+
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
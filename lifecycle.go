@@ -0,0 +1,116 @@
+package lile
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Hook is a function run when the service starts or stops
+type Hook func(ctx context.Context) error
+
+type worker struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Go runs a background worker, such as a pubsub subscriber, for as long as
+// the service runs. Workers added before Run are started by Run. The
+// context is cancelled when the service shuts down, and if a worker returns
+// an error the service shuts down and Run returns the error
+func (s *Service) Go(name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := worker{name: name, fn: fn}
+	if s.group == nil {
+		s.workers = append(s.workers, w)
+		return
+	}
+
+	s.startWorker(w)
+}
+
+// startWorkers creates the group that workers and the gRPC server run in.
+// When any of them fail the service is shut down. It must be called with
+// mu held
+func (s *Service) startWorkers() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, gctx := errgroup.WithContext(ctx)
+
+	s.group = g
+	s.workerCtx = gctx
+	s.stopWorkers = cancel
+	for _, w := range s.workers {
+		s.startWorker(w)
+	}
+
+	go func() {
+		<-gctx.Done()
+
+		// The group failed, rather than being stopped by Shutdown. Its
+		// context is also cancelled when Run finishes waiting for it, which
+		// only happens once shutdown has begun
+		st := s.State()
+		if ctx.Err() == nil && (st == StateStarting || st == StateServing) {
+			s.log().Error("lile: service failed, shutting down", "service", s.Name)
+			s.Shutdown()
+		}
+	}()
+}
+
+func (s *Service) startWorker(w worker) {
+	ctx := s.workerCtx
+	s.group.Go(func() error {
+		s.log().Info("lile: starting worker", "worker", w.name)
+		err := w.fn(ctx)
+
+		// Returning the cancellation error while stopping isn't a failure
+		if err != nil && ctx.Err() != nil && err == ctx.Err() {
+			err = nil
+		}
+
+		if err != nil {
+			s.log().Error("lile: worker failed", "worker", w.name, "error", err)
+		}
+
+		return err
+	})
+}
+
+// waitForWorkers cancels the workers' context and waits for them to return
+// until the context is done
+func (s *Service) waitForWorkers(ctx context.Context) {
+	s.mu.Lock()
+	g, stop := s.group, s.stopWorkers
+	s.mu.Unlock()
+
+	if g == nil {
+		return
+	}
+
+	stop()
+
+	done := make(chan struct{})
+	go func() {
+		g.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.log().Warn("lile: shutdown timeout exceeded waiting for workers", "timeout", s.ShutdownTimeout)
+	}
+}
+
+// runHooks runs hooks in order, stopping at the first error
+func runHooks(ctx context.Context, hooks []Hook) error {
+	for _, h := range hooks {
+		if err := h(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package lile
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerFailureStopsService(t *testing.T) {
+	s := testService(t, "workers")
+	failed := errors.New("subscriber lost connection")
+
+	stopped := make(chan struct{})
+	s.OnStop = append(s.OnStop, func(ctx context.Context) error {
+		close(stopped)
+		return nil
+	})
+
+	s.Go("subscriber", func(ctx context.Context) error {
+		return failed
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run() }()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, failed, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run didn't return after a worker failed")
+	}
+
+	<-stopped
+}
+
+func TestWorkersStopOnShutdown(t *testing.T) {
+	s := testService(t, "workers")
+
+	started := false
+	s.OnStart = append(s.OnStart, func(ctx context.Context) error {
+		started = true
+		return nil
+	})
+
+	s.Go("before", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+	assert.True(t, started)
+
+	cancelled := make(chan struct{})
+	s.Go("after", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(cancelled)
+		return nil
+	})
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+	<-cancelled
+}
+
+func TestStartHookFailure(t *testing.T) {
+	s := testService(t, "hooks")
+	failed := errors.New("migrations failed")
+	s.OnStart = append(s.OnStart, func(ctx context.Context) error {
+		return failed
+	})
+
+	assert.Equal(t, failed, s.Run())
+}
+
+func TestRunWaitsForStopHooks(t *testing.T) {
+	s := testService(t, "stop-hooks")
+
+	var stopped int32
+	s.OnStop = append(s.OnStop, func(ctx context.Context) error {
+		time.Sleep(200 * time.Millisecond)
+		atomic.StoreInt32(&stopped, 1)
+		return nil
+	})
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	go s.Shutdown()
+
+	assert.Nil(t, <-errs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stopped))
+	assert.Equal(t, StateStopped, s.State())
+}
+
+func TestShutdownIsNotLoggedAsFailure(t *testing.T) {
+	logger := newRecordingLogger()
+	s := testService(t, "clean-stop")
+	s.Logger = logger
+	s.SinglePort = true
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+
+	// The failure is logged from a goroutine, give it a chance to run
+	time.Sleep(50 * time.Millisecond)
+	for {
+		select {
+		case e := <-logger.entries:
+			assert.NotEqual(t, "error", e.level, e.msg)
+		default:
+			return
+		}
+	}
+}
@@ -8,12 +8,16 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
-	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/lileio/fromenv"
+	"github.com/lileio/lile/v2/errors"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -28,10 +32,43 @@ type RegisterImplementation func(s *grpc.Server)
 type ServerConfig struct {
 	Port int
 	Host string
+
+	// Socket is a unix socket path to listen on instead of Host and Port
+	Socket string
+
+	// Systemd inherits the listening socket from systemd socket activation
+	// when the process was started that way
+	Systemd bool
+
+	// TLSCert and TLSKey enable TLS. TLSClientCA enables mutual TLS, where
+	// clients must present a certificate signed by the CA. The files are
+	// reloaded when they change on disk
+	TLSCert     string
+	TLSKey      string
+	TLSClientCA string
+}
+
+// ClientConfig is the TLS configuration for dialing other services, it is
+// kept apart from the server's certificates as clients are usually issued
+// their own
+type ClientConfig struct {
+	// TLSRootCA is a CA bundle for verifying server certificates, it
+	// enables TLS
+	TLSRootCA string
+
+	// TLSCert and TLSKey are presented as the client certificate to
+	// servers that require mutual TLS. They also enable TLS, verifying
+	// servers against the system roots when TLSRootCA isn't set
+	TLSCert string
+	TLSKey  string
 }
 
 // Address Gets a logical addr for a ServerConfig
 func (c *ServerConfig) Address() string {
+	if c.Socket != "" {
+		return c.Socket
+	}
+
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
@@ -52,31 +89,143 @@ type Service struct {
 	Config           ServerConfig
 	PrometheusConfig ServerConfig
 
+	// ClientConfig is used by ClientTransportOption to dial other services
+	ClientConfig ClientConfig
+
+	// Telemetry configures the OpenTelemetry tracing and metrics
+	// interceptors, which run ahead of the other interceptors. When nil
+	// the global OpenTelemetry providers are used
+	Telemetry *Telemetry
+
+	// Logger receives the service's logs, it defaults to the standard
+	// logrus logger. AccessLog controls the log of every RPC
+	Logger    Logger
+	AccessLog AccessLogConfig
+
+	// Auth authenticates every call that isn't exempt, once an
+	// Authenticator is set, and authorizes calls against Auth.Policy
+	Auth AuthConfig
+
+	// RateLimiter limits calls per caller once it has limits, the
+	// BaseCommand flags add them
+	RateLimiter *RateLimiter
+
+	// RecoveryHandler maps panics in handlers to the error returned, it
+	// defaults to codes.Internal
+	RecoveryHandler RecoveryHandler
+
+	// Idempotency replays the result of unary calls retried with the same
+	// idempotency-key header, once a Store and Methods are set
+	Idempotency IdempotencyConfig
+
+	// Deadlines are applied to calls, so handlers don't run forever when
+	// callers don't set a deadline
+	Deadlines DeadlineConfig
+
+	// Concurrency sheds requests once the server is overloaded, it is off
+	// unless Concurrency.MaxLimit is set
+	Concurrency ConcurrencyConfig
+
 	// Registry allows Lile to work with external registeries like
 	// consul, zookeeper or similar
 	Registry Registry
 
-	// Private utils, exposed so they can be useful if needed
+	// HealthCheckers are probed every HealthCheckInterval and decide the
+	// status reported by the grpc.health.v1.Health service
+	HealthCheckers      []HealthChecker
+	HealthCheckInterval time.Duration
+
+	// EnableReflection registers the gRPC server reflection service, so
+	// tools like grpcurl can discover the service. ReflectionDescriptors
+	// optionally holds a serialized FileDescriptorSet for when the generated
+	// code doesn't carry enough descriptors
+	EnableReflection      bool
+	ReflectionDescriptors []byte
+
+	// GatewayRoutes are served as HTTP/JSON on GatewayConfig, or on the
+	// gRPC port in single port mode. They are generated by
+	// protoc-gen-lile-server from google.api.http annotations
+	GatewayRoutes []GatewayRoute
+	GatewayConfig ServerConfig
+
+	// OnStart hooks run before Run starts serving, an error stops Run.
+	// OnStop hooks run during Shutdown once the servers and workers have
+	// stopped
+	OnStart []Hook
+	OnStop  []Hook
+
+	// PreStopDelay is how long Shutdown waits after marking the service
+	// NOT_SERVING, so load balancers stop routing to it before it drains.
+	// ShutdownTimeout bounds how long in-flight RPCs are given to finish
+	// before they are stopped forcefully, it defaults to 30 seconds which is
+	// the default grace period in Kubernetes
+	PreStopDelay    time.Duration
+	ShutdownTimeout time.Duration
+
+	// EnablePprof serves net/http/pprof on the admin server under
+	// /debug/pprof/
+	EnablePprof bool
+
+	// MetricsOptional lets Run continue without the metrics and admin
+	// server when its port can't be bound, which is handy when running
+	// several services locally. By default Run returns the bind error
+	MetricsOptional bool
+
+	// SinglePort serves gRPC, metrics and HTTP handlers on the gRPC
+	// listener instead of a separate metrics port
+	SinglePort bool
+
+	// Private utils, exposed so they can be useful if needed.
+	// ServiceListener can be set before calling Run to serve on an
+	// existing listener instead of the one described by Config
 	ServiceListener  net.Listener
 	GRPCServer       *grpc.Server
+	HealthServer     *health.Server
 	PrometheusServer *http.Server
+	HTTPServer       *http.Server
+	GatewayServer    *http.Server
+
+	mu          sync.Mutex
+	state       State
+	done        chan struct{}
+	workers     []worker
+	group       *errgroup.Group
+	workerCtx   context.Context
+	stopWorkers context.CancelFunc
+
+	httpMux           *http.ServeMux
+	http2Conns        *http2Conns
+	adminHandlers     map[string]http.Handler
+	gateway           *Gateway
+	gatewayConnection *grpc.ClientConn
+	stopHealthChecks  context.CancelFunc
 }
 
 // NewService creates a new service with a given name
 func NewService(n string) *Service {
 	return &Service{
-		ID:                 generateID(n),
-		Name:               n,
-		Config:             ServerConfig{Host: "0.0.0.0", Port: 8000},
-		PrometheusConfig:   ServerConfig{Host: "0.0.0.0", Port: 9000},
-		GRPCImplementation: func(s *grpc.Server) {},
+		ID:                  generateID(n),
+		Name:                n,
+		Config:              ServerConfig{Host: "0.0.0.0", Port: 8000},
+		PrometheusConfig:    ServerConfig{Host: "0.0.0.0", Port: 9000},
+		GatewayConfig:       ServerConfig{Host: "0.0.0.0", Port: 8080},
+		HealthCheckInterval: 10 * time.Second,
+		ShutdownTimeout:     30 * time.Second,
+		Logger:              defaultLogger,
+		AccessLog:           AccessLogConfig{SampleRate: 1},
+		RateLimiter:         NewRateLimiter(),
+		GRPCImplementation:  func(s *grpc.Server) {},
+		// Errors are translated inside the metrics interceptor, so it
+		// counts the codes callers see
 		UnaryInts: []grpc.UnaryServerInterceptor{
 			grpc_prometheus.UnaryServerInterceptor,
-			grpc_recovery.UnaryServerInterceptor(),
+			errors.UnaryServerInterceptor(),
+			ValidationUnaryInterceptor(),
 		},
 		StreamInts: []grpc.StreamServerInterceptor{
 			grpc_prometheus.StreamServerInterceptor,
-			grpc_recovery.StreamServerInterceptor(),
+			errors.StreamServerInterceptor(),
+			ValidationStreamInterceptor(),
 		},
 	}
 }
@@ -86,36 +235,87 @@ func GlobalService() *Service {
 	return service
 }
 
-// Name sets the name for the service
+// Name sets the name for the global service
 func Name(n string) {
-	service.ID = generateID(n)
-	service.Name = n
-	AddUnaryInterceptor(otgrpc.OpenTracingServerInterceptor(
-		fromenv.Tracer(n)))
+	service.SetName(n)
 }
 
-// Server attaches the gRPC implementation to the service
+// Server attaches the gRPC implementation to the global service
 func Server(r func(s *grpc.Server)) {
 	service.GRPCImplementation = r
 }
 
-// AddUnaryInterceptor adds a unary interceptor to the RPC server
+// AddUnaryInterceptor adds a unary interceptor to the global RPC server
 func AddUnaryInterceptor(unint grpc.UnaryServerInterceptor) {
-	service.UnaryInts = append(service.UnaryInts, unint)
+	service.AddUnaryInterceptor(unint)
 }
 
-// AddStreamInterceptor adds a stream interceptor to the RPC server
+// AddStreamInterceptor adds a stream interceptor to the global RPC server
 func AddStreamInterceptor(sint grpc.StreamServerInterceptor) {
-	service.StreamInts = append(service.StreamInts, sint)
+	service.AddStreamInterceptor(sint)
+}
+
+// URLForService returns a service URL via the global service's registry or
+// a simple DNS name if not available via the registry
+func URLForService(name string) string {
+	return service.URLForService(name)
+}
+
+// SetName sets the name for the service and adds unary and stream tracing
+// interceptors using the tracer configured in the environment
+func (s *Service) SetName(n string) {
+	s.ID = generateID(n)
+	s.Name = n
+
+	tracer := fromenv.Tracer(n)
+	s.AddUnaryInterceptor(otgrpc.OpenTracingServerInterceptor(tracer))
+	s.AddStreamInterceptor(otgrpc.OpenTracingStreamServerInterceptor(tracer))
+}
+
+// AddUnaryInterceptor adds a unary interceptor to the RPC server
+func (s *Service) AddUnaryInterceptor(unint grpc.UnaryServerInterceptor) {
+	s.UnaryInts = append(s.UnaryInts, unint)
+}
+
+// AddStreamInterceptor adds a stream interceptor to the RPC server
+func (s *Service) AddStreamInterceptor(sint grpc.StreamServerInterceptor) {
+	s.StreamInts = append(s.StreamInts, sint)
+}
+
+// Address returns the address the gRPC server is listening on. Once the
+// service has started this is the bound address, so it holds the real port
+// when Config.Port is 0. Registries should use it rather than Config
+func (s *Service) Address() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ServiceListener != nil {
+		return s.ServiceListener.Addr().String()
+	}
+
+	return s.Config.Address()
+}
+
+// MetricsAddress returns the address the metrics and admin server is
+// listening on, with the real port once it has started
+func (s *Service) MetricsAddress() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.PrometheusServer != nil {
+		return s.PrometheusServer.Addr
+	}
+
+	return s.PrometheusConfig.Address()
 }
 
 // URLForService returns a service URL via a registry or a simple DNS name
 // if not available via the registry
-func URLForService(name string) string {
-	if service.Registry != nil {
-		url, err := service.Registry.Get(name)
+func (s *Service) URLForService(name string) string {
+	if s.Registry != nil {
+		url, err := s.Registry.Get(name)
 		if err != nil {
-			fmt.Printf("lile: error contacting registry for service %s. err: %s \n", name, err.Error())
+			s.log().Error("lile: error contacting registry", "service", name, "error", err)
 		}
 		return url
 	}
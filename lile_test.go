@@ -0,0 +1,105 @@
+package lile
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func testService(t *testing.T, name string) *Service {
+	s := NewService(name)
+	s.Config = ServerConfig{Host: "127.0.0.1", Port: freePort(t)}
+	s.PrometheusConfig = ServerConfig{Host: "127.0.0.1", Port: freePort(t)}
+	return s
+}
+
+func TestServiceInterceptorsAreIndependent(t *testing.T) {
+	a := NewService("a")
+	b := NewService("b")
+
+	a.AddUnaryInterceptor(func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(ctx, req)
+	})
+
+	assert.Len(t, a.UnaryInts, len(b.UnaryInts)+1)
+	assert.Len(t, GlobalService().UnaryInts, len(b.UnaryInts))
+}
+
+func TestRunMultipleServices(t *testing.T) {
+	services := []*Service{testService(t, "public"), testService(t, "admin")}
+
+	errs := make(chan error, len(services))
+	for _, s := range services {
+		go func(s *Service) {
+			errs <- s.Run()
+		}(s)
+	}
+
+	for _, s := range services {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		conn, err := grpc.DialContext(ctx, s.Config.Address(),
+			grpc.WithInsecure(), grpc.WithBlock())
+		cancel()
+		assert.Nil(t, err)
+		conn.Close()
+	}
+
+	for _, s := range services {
+		s.Shutdown()
+		assert.Nil(t, <-errs)
+	}
+}
+
+type addressRegistry struct {
+	registered chan string
+}
+
+func (r *addressRegistry) Register(s *Service) error {
+	r.registered <- s.Address()
+	return nil
+}
+
+func (r *addressRegistry) DeRegister(s *Service) error { return nil }
+
+func (r *addressRegistry) Get(name string) (string, error) { return "", nil }
+
+func TestEphemeralPort(t *testing.T) {
+	reg := &addressRegistry{registered: make(chan string, 1)}
+
+	s := NewService("ephemeral")
+	s.Config = ServerConfig{Host: "127.0.0.1", Port: 0}
+	s.PrometheusConfig = ServerConfig{Host: "127.0.0.1", Port: 0}
+	s.Registry = reg
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	addr := <-reg.registered
+	assert.NotEqual(t, "127.0.0.1:0", addr)
+	assert.Equal(t, addr, s.Address())
+	assert.NotEqual(t, "127.0.0.1:0", s.MetricsAddress())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	cancel()
+	assert.Nil(t, err)
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
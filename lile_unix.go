@@ -3,7 +3,21 @@
 package lile
 
 import (
+	"fmt"
 	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// systemd passes sockets starting at this file descriptor
+const listenFdsStart = 3
+
+var (
+	systemdMu    sync.Mutex
+	systemdFiles = map[int]*os.File{}
 )
 
 func formatPlatformTestSeverAddress(uniquePortion string)(string) {
@@ -17,3 +31,48 @@ func getTestServerListener(address string)(net.Listener, error) {
 func dialTestServer(address string)(net.Conn, error) {
 	return  net.Dial("unix", address)
 }
+
+// systemdListener returns a listener for a socket passed by systemd socket
+// activation (LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES). It returns nil if
+// the process wasn't socket activated
+func systemdListener(name string) (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+
+	nfds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || nfds == 0 {
+		return nil, nil
+	}
+
+	// With a single socket the name doesn't matter, otherwise it has to
+	// match the FileDescriptorName of the systemd socket unit
+	idx := 0
+	if nfds > 1 {
+		idx = -1
+		for i, n := range strings.Split(os.Getenv("LISTEN_FDNAMES"), ":") {
+			if n == name && i < nfds {
+				idx = i
+			}
+		}
+
+		if idx == -1 {
+			return nil, fmt.Errorf("lile: no systemd socket named %q", name)
+		}
+	}
+
+	fd := listenFdsStart + idx
+
+	systemdMu.Lock()
+	defer systemdMu.Unlock()
+
+	f, ok := systemdFiles[fd]
+	if !ok {
+		syscall.CloseOnExec(fd)
+		f = os.NewFile(uintptr(fd), name)
+		systemdFiles[fd] = f
+	}
+
+	return net.FileListener(f)
+}
@@ -3,6 +3,7 @@
 package lile
 
 import (
+	"errors"
 	"github.com/natefinch/npipe"
 	"net"
 )
@@ -18,3 +19,8 @@ func getTestServerListener(address string)(net.Listener, error) {
 func dialTestServer(address string)(net.Conn, error) {
 	return  npipe.Dial(address)
 }
+
+// systemdListener isn't available on Windows
+func systemdListener(name string) (net.Listener, error) {
+	return nil, errors.New("lile: systemd socket activation is not supported on windows")
+}
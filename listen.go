@@ -0,0 +1,56 @@
+package lile
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// Network returns the network the config listens on, "unix" when a socket
+// path is configured and "tcp" otherwise
+func (c *ServerConfig) Network() string {
+	if c.Socket != "" {
+		return "unix"
+	}
+
+	return "tcp"
+}
+
+// Listen creates a listener for the config. A socket inherited from systemd
+// takes priority, followed by a unix socket path and then a TCP address.
+// The name is matched against LISTEN_FDNAMES when systemd passes more than
+// one socket
+func (c *ServerConfig) Listen(name string) (net.Listener, error) {
+	if c.Systemd {
+		l, err := systemdListener(name)
+		if err != nil || l != nil {
+			return l, err
+		}
+	}
+
+	if c.Socket != "" {
+		if err := removeStaleSocket(c.Socket); err != nil {
+			return nil, err
+		}
+	}
+
+	return net.Listen(c.Network(), c.Address())
+}
+
+// removeStaleSocket removes a socket left behind by a previous process. Any
+// other file at the path is left alone, so a mistyped path can't delete it
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("lile: %s exists and isn't a socket", path)
+	}
+
+	return os.Remove(path)
+}
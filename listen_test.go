@@ -0,0 +1,93 @@
+// +build !windows
+
+package lile
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServeUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-socket")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	s := testService(t, "socket")
+	s.Config.Socket = filepath.Join(dir, "grpc.sock")
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	conn := TestConn(s.Config.Address())
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestServeSuppliedListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-socket")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	addr := filepath.Join(dir, "supplied.sock")
+	l, err := net.Listen("unix", addr)
+	assert.Nil(t, err)
+
+	s := testService(t, "supplied")
+	s.ServiceListener = l
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	conn := TestConn(addr)
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestListenStaleSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-socket")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// A socket left behind by a previous process is replaced
+	path := filepath.Join(dir, "grpc.sock")
+	stale, err := net.Listen("unix", path)
+	assert.Nil(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	c := &ServerConfig{Socket: path}
+	l, err := c.Listen("grpc")
+	assert.Nil(t, err)
+	l.Close()
+
+	// but any other file is left alone
+	file := filepath.Join(dir, "config.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("keep me"), 0644))
+
+	c = &ServerConfig{Socket: file}
+	_, err = c.Listen("grpc")
+	assert.NotNil(t, err)
+
+	b, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "keep me", string(b))
+}
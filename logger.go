@@ -0,0 +1,75 @@
+package lile
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+// Logger is a structured logger. Fields are given as alternating keys and
+// values, e.g. Info("serving", "addr", addr)
+type Logger interface {
+	Debug(msg string, fields ...interface{})
+	Info(msg string, fields ...interface{})
+	Warn(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+}
+
+// defaultLogger is used when a service has no Logger
+var defaultLogger Logger = NewLogrusLogger(logrus.StandardLogger())
+
+// log returns the service's logger, or the default one
+func (s *Service) log() Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+
+	return defaultLogger
+}
+
+// NewLogrusLogger adapts a logrus logger or entry
+func NewLogrusLogger(l logrus.FieldLogger) Logger {
+	return logrusLogger{l}
+}
+
+type logrusLogger struct {
+	l logrus.FieldLogger
+}
+
+func (l logrusLogger) with(fields []interface{}) logrus.FieldLogger {
+	if len(fields) == 0 {
+		return l.l
+	}
+
+	f := logrus.Fields{}
+	for i := 0; i < len(fields); i += 2 {
+		key := fmt.Sprint(fields[i])
+		if i+1 < len(fields) {
+			f[key] = fields[i+1]
+		} else {
+			f[key] = nil
+		}
+	}
+
+	return l.l.WithFields(f)
+}
+
+func (l logrusLogger) Debug(msg string, fields ...interface{}) { l.with(fields).Debug(msg) }
+func (l logrusLogger) Info(msg string, fields ...interface{})  { l.with(fields).Info(msg) }
+func (l logrusLogger) Warn(msg string, fields ...interface{})  { l.with(fields).Warn(msg) }
+func (l logrusLogger) Error(msg string, fields ...interface{}) { l.with(fields).Error(msg) }
+
+// NewZapLogger adapts a zap logger
+func NewZapLogger(l *zap.Logger) Logger {
+	return zapLogger{l.Sugar()}
+}
+
+type zapLogger struct {
+	l *zap.SugaredLogger
+}
+
+func (l zapLogger) Debug(msg string, fields ...interface{}) { l.l.Debugw(msg, fields...) }
+func (l zapLogger) Info(msg string, fields ...interface{})  { l.l.Infow(msg, fields...) }
+func (l zapLogger) Warn(msg string, fields ...interface{})  { l.l.Warnw(msg, fields...) }
+func (l zapLogger) Error(msg string, fields ...interface{}) { l.l.Errorw(msg, fields...) }
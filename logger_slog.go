@@ -0,0 +1,34 @@
+//go:build go1.21
+// +build go1.21
+
+package lile
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger adapts a log/slog logger
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (l slogLogger) Debug(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelDebug, msg, fields...)
+}
+
+func (l slogLogger) Info(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelInfo, msg, fields...)
+}
+
+func (l slogLogger) Warn(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelWarn, msg, fields...)
+}
+
+func (l slogLogger) Error(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelError, msg, fields...)
+}
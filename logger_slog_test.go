@@ -0,0 +1,22 @@
+//go:build go1.21
+// +build go1.21
+
+package lile
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	NewSlogLogger(l).Info("hello", "method", "/pkg.Svc/Get")
+	assert.Contains(t, buf.String(), "level=INFO")
+	assert.Contains(t, buf.String(), "msg=hello")
+	assert.Contains(t, buf.String(), "method=/pkg.Svc/Get")
+}
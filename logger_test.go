@@ -0,0 +1,63 @@
+package lile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordingLogger keeps every entry for tests to inspect
+type recordingLogger struct {
+	entries chan logEntry
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{entries: make(chan logEntry, 100)}
+}
+
+func (l *recordingLogger) log(level, msg string, fields []interface{}) {
+	f := map[string]interface{}{}
+	for i := 0; i+1 < len(fields); i += 2 {
+		f[fields[i].(string)] = fields[i+1]
+	}
+
+	l.entries <- logEntry{level: level, msg: msg, fields: f}
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...interface{}) { l.log("debug", msg, fields) }
+func (l *recordingLogger) Info(msg string, fields ...interface{})  { l.log("info", msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields ...interface{})  { l.log("warn", msg, fields) }
+func (l *recordingLogger) Error(msg string, fields ...interface{}) { l.log("error", msg, fields) }
+
+func TestLogrusLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.Out = &buf
+	l.Formatter = &logrus.JSONFormatter{}
+
+	NewLogrusLogger(l).Warn("hello", "method", "/pkg.Svc/Get", "code", "OK")
+	assert.Contains(t, buf.String(), `"level":"warning"`)
+	assert.Contains(t, buf.String(), `"method":"/pkg.Svc/Get"`)
+	assert.Contains(t, buf.String(), `"msg":"hello"`)
+}
+
+func TestZapLogger(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+
+	NewZapLogger(zap.New(core)).Error("hello", "method", "/pkg.Svc/Get")
+	if assert.Equal(t, 1, logs.Len()) {
+		e := logs.All()[0]
+		assert.Equal(t, "hello", e.Message)
+		assert.Equal(t, "/pkg.Svc/Get", e.ContextMap()["method"])
+	}
+}
@@ -0,0 +1,234 @@
+package lile
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+)
+
+// HandleHTTP registers a HTTP handler that is served next to the admin
+// endpoints, on the metrics port or on the gRPC port when running in single
+// port mode
+func (s *Service) HandleHTTP(pattern string, handler http.Handler) {
+	if s.httpMux == nil {
+		s.httpMux = http.NewServeMux()
+	}
+
+	s.httpMux.Handle(pattern, handler)
+}
+
+// httpHandler routes to the admin endpoints, then to the gateway in single
+// port mode, falling back to the handlers registered with HandleHTTP. The
+// built in routes come first so a catch-all pattern like / can't hide them
+func (s *Service) httpHandler() http.Handler {
+	mux := s.httpMux
+	gw := s.gateway
+	admin := s.adminMux()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, pattern := admin.Handler(r); pattern != "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if gw != nil && gw.Handles(r) {
+			gw.ServeHTTP(w, r)
+			return
+		}
+
+		if mux != nil {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		http.NotFound(w, r)
+	})
+}
+
+// serveMultiplexed serves gRPC, metrics and HTTP handlers on the gRPC
+// listener. Connections are routed by protocol, HTTP/2 requests with a
+// content-type of application/grpc go to the gRPC server and everything
+// else to the HTTP handlers
+func (s *Service) serveMultiplexed(gs *grpc.Server) error {
+	l := s.ServiceListener
+	if s.Config.TLSEnabled() {
+		cfg, err := s.Config.serverTLSConfig(s.log())
+		if err != nil {
+			return err
+		}
+
+		cfg.NextProtos = []string{"h2", "http/1.1"}
+		l = tls.NewListener(l, cfg)
+	}
+
+	m := cmux.New(l)
+	grpcL := m.MatchWithWriters(
+		cmux.HTTP2MatchHeaderFieldPrefixSendSettings("content-type", "application/grpc"))
+	h2L := m.Match(cmux.HTTP2())
+	httpL := m.Match(cmux.Any())
+
+	// HTTP/2 connections are registered with the HTTP server, so shutting
+	// it down sends them a GOAWAY
+	handler := s.httpHandler()
+	hs := &http.Server{Handler: handler}
+	h2s := &http2.Server{}
+	if err := http2.ConfigureServer(hs, h2s); err != nil {
+		l.Close()
+		return err
+	}
+
+	h2 := &http2Conns{conns: map[net.Conn]struct{}{}}
+	s.mu.Lock()
+	s.HTTPServer = hs
+	s.http2Conns = h2
+	s.mu.Unlock()
+
+	go hs.Serve(httpL)
+	go h2.serve(h2L, h2s, &http2.ServeConnOpts{BaseConfig: hs, Handler: handler})
+
+	// Closing the gRPC listener closes the root listener, so errors from
+	// the multiplexer only mirror those returned by the gRPC server
+	go m.Serve()
+
+	err := gs.Serve(grpcL)
+
+	// Make sure the HTTP listeners stop if the gRPC server never served
+	l.Close()
+	return err
+}
+
+// http2Conns tracks the HTTP/2 connections that aren't gRPC, such as
+// browsers over TLS or h2c clients with prior knowledge. They're served
+// outside the HTTP server, so the drain waits for them itself
+type http2Conns struct {
+	mu      sync.Mutex
+	conns   map[net.Conn]struct{}
+	stopped bool
+}
+
+func (c *http2Conns) serve(l net.Listener, srv *http2.Server, opts *http2.ServeConnOpts) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		if !c.add(conn) {
+			conn.Close()
+			continue
+		}
+
+		go func() {
+			defer c.remove(conn)
+			srv.ServeConn(&settingsAckConn{Conn: conn}, opts)
+		}()
+	}
+}
+
+func (c *http2Conns) add(conn net.Conn) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped {
+		return false
+	}
+
+	c.conns[conn] = struct{}{}
+	return true
+}
+
+func (c *http2Conns) remove(conn net.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.conns, conn)
+}
+
+// shutdown waits for the connections to finish until the context is done,
+// then closes those remaining. New connections are refused from here on
+func (c *http2Conns) shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.stopped = true
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		c.mu.Lock()
+		n := len(c.conns)
+		if n == 0 || ctx.Err() != nil {
+			for conn := range c.conns {
+				conn.Close()
+			}
+			c.mu.Unlock()
+
+			if n > 0 {
+				return ctx.Err()
+			}
+
+			return nil
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+// settingsAckConn drops the client's first SETTINGS ACK. Matching gRPC
+// connections sends the client a SETTINGS frame before they reach the HTTP/2
+// server, which treats the ACK for it as a protocol error
+type settingsAckConn struct {
+	net.Conn
+	pending bytes.Buffer
+	preface bool
+	dropped bool
+}
+
+func (c *settingsAckConn) Read(p []byte) (int, error) {
+	for c.pending.Len() == 0 && !c.dropped {
+		if err := c.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	if c.pending.Len() > 0 {
+		return c.pending.Read(p)
+	}
+
+	return c.Conn.Read(p)
+}
+
+// next reads the preface or the next frame into pending, unless it's the
+// ACK being dropped
+func (c *settingsAckConn) next() error {
+	if !c.preface {
+		c.preface = true
+		_, err := io.CopyN(&c.pending, c.Conn, int64(len(http2.ClientPreface)))
+		return err
+	}
+
+	h, err := http2.ReadFrameHeader(io.TeeReader(c.Conn, &c.pending))
+	if err != nil {
+		return err
+	}
+
+	if h.Type == http2.FrameSettings && h.Flags.Has(http2.FlagSettingsAck) {
+		c.pending.Reset()
+		c.dropped = true
+		return nil
+	}
+
+	_, err = io.CopyN(&c.pending, c.Conn, int64(h.Length))
+	return err
+}
@@ -0,0 +1,191 @@
+package lile
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func httpGet(t *testing.T, url string) (int, string) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	b, _ := ioutil.ReadAll(res.Body)
+	return res.StatusCode, string(b)
+}
+
+func TestSinglePort(t *testing.T) {
+	s := testService(t, "single")
+	s.SinglePort = true
+	s.HandleHTTP("/hello", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, s, ""))
+
+	code, body := httpGet(t, "http://"+s.Config.Address()+"/hello")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "hello", body)
+
+	code, body = httpGet(t, "http://"+s.Config.Address()+"/metrics")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "grpc_server_handled_total")
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestSinglePortCatchAll(t *testing.T) {
+	s := testService(t, "single-catch-all")
+	s.SinglePort = true
+	s.GatewayRoutes = healthRoutes
+	s.HandleHTTP("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("catch-all"))
+	}))
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	// The built in routes aren't hidden by a catch-all pattern
+	base := "http://" + s.Config.Address()
+	code, body := httpGet(t, base+"/metrics")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "grpc_server_handled_total")
+
+	code, _ = httpGet(t, base+"/readyz")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, body = httpGet(t, base+"/v1/health/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status":"SERVING"}`, body)
+
+	code, body = httpGet(t, base+"/anything")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "catch-all", body)
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+// h2cClient speaks HTTP/2 with prior knowledge over plain TCP
+func h2cClient() *http.Client {
+	return &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+}
+
+func TestSinglePortHTTP2(t *testing.T) {
+	s := testService(t, "single-h2c")
+	s.SinglePort = true
+	s.HandleHTTP("/peer", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	}))
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	// Requests share a connection, rather than it failing after the first
+	c := h2cClient()
+	peers := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		res, err := c.Get("http://" + s.Config.Address() + "/peer")
+		if !assert.Nil(t, err) {
+			break
+		}
+
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		assert.Equal(t, 2, res.ProtoMajor)
+		peers[string(b)] = true
+	}
+
+	assert.Len(t, peers, 1)
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestSinglePortDrainsHTTP2(t *testing.T) {
+	s := testService(t, "single-h2")
+	s.SinglePort = true
+	s.ShutdownTimeout = 200 * time.Millisecond
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	s.HandleHTTP("/slow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte("done"))
+	}))
+	s.HandleHTTP("/stuck", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	get := func(path string) <-chan error {
+		res := make(chan error, 1)
+		go func() {
+			r, err := h2cClient().Get("http://" + s.Config.Address() + path)
+			if err == nil {
+				_, err = ioutil.ReadAll(r.Body)
+				r.Body.Close()
+			}
+			res <- err
+		}()
+		return res
+	}
+
+	slow := get("/slow")
+	stuck := get("/stuck")
+	<-started
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Shutdown()
+		close(stopped)
+	}()
+
+	// In-flight requests finish, and connections still busy at the
+	// timeout are closed
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	assert.Nil(t, <-slow)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown didn't close HTTP/2 connections")
+	}
+
+	select {
+	case err := <-stuck:
+		assert.NotNil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown left a HTTP/2 connection open")
+	}
+
+	assert.Nil(t, <-errs)
+}
@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/rakyll/statik/fs"
@@ -22,6 +23,9 @@ import (
 	"github.com/fatih/color"
 	"github.com/golang/protobuf/proto"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"google.golang.org/genproto/googleapis/api/annotations"
+
+	"github.com/lileio/lile/v2/options"
 
 	_ "github.com/lileio/lile/v2/protoc-gen-lile-server/statik" // TODO: Replace with the absolute import path
 )
@@ -45,6 +49,37 @@ type grpcMethod struct {
 	OutputImport string
 }
 
+// gatewayRoute is a HTTP binding of a unary method from a google.api.http
+// annotation
+type gatewayRoute struct {
+	Method     string
+	Pattern    string
+	Body       string
+	GRPCMethod string
+	InType     string
+	OutType    string
+}
+
+// methodPolicy is the authorization policy of a method from a lile.auth
+// option
+type methodPolicy struct {
+	GRPCMethod string
+	Roles      []string
+	Public     bool
+}
+
+// methodTimeout is the server side deadline of a method from a
+// lile.timeout option
+type methodTimeout struct {
+	GRPCMethod string
+	Timeout    time.Duration
+}
+
+type gatewayFile struct {
+	Routes  []gatewayRoute
+	Imports string
+}
+
 // Used to import other packages correctly, for example..
 // Package: "google.protobuf"
 // GoPackage: "github.com/golang/protobuf/ptypes/empty"
@@ -82,6 +117,10 @@ func gen(i io.Reader, o io.Writer) {
 
 	files := []*plugin.CodeGeneratorResponse_File{}
 	imports := []goimport{}
+	routes := []gatewayRoute{}
+	routeImports := []string{}
+	policies := []methodPolicy{}
+	timeouts := []methodTimeout{}
 
 	for _, file := range req.ProtoFile {
 		pkgParts := strings.Split(file.GetOptions().GetGoPackage(), "/")
@@ -124,6 +163,48 @@ func gen(i io.Reader, o io.Writer) {
 					OutputImport: outputImport(imports, method),
 				}
 
+				grpcMethod := fmt.Sprintf("/%s.%s/%s", file.GetPackage(), gm.ServiceName, gm.Name)
+
+				if rule := authRule(method); rule != nil {
+					policies = append(policies, methodPolicy{
+						GRPCMethod: grpcMethod,
+						Roles:      rule.GetRoles(),
+						Public:     rule.GetPublic(),
+					})
+				}
+
+				timeout, err := methodTimeoutOption(method)
+				if err != nil {
+					emitError(fmt.Errorf("%s: %v", grpcMethod, err))
+					log.Fatal(err)
+				}
+
+				if timeout > 0 {
+					timeouts = append(timeouts, methodTimeout{
+						GRPCMethod: grpcMethod,
+						Timeout:    timeout,
+					})
+				}
+
+				for _, rule := range httpRules(method) {
+					if gm.ClientStreaming || gm.ServerStreaming {
+						log.Printf("%s %s.%s, streaming methods can't be bound to HTTP",
+							color.YellowString("[Skipping route]"), gm.ServiceName, gm.Name)
+						break
+					}
+
+					routes = append(routes, gatewayRoute{
+						Method:     httpMethod(rule),
+						Pattern:    httpPattern(rule),
+						Body:       rule.GetBody(),
+						GRPCMethod: grpcMethod,
+						InType:     gm.InType,
+						OutType:    gm.OutType,
+					})
+
+					routeImports = append(routeImports, gm.InputImport, gm.OutputImport)
+				}
+
 				f, err := generateMethod(path, gm)
 				if err != nil {
 					emitError(err)
@@ -139,6 +220,29 @@ func gen(i io.Reader, o io.Writer) {
 		}
 	}
 
+	f, err := generateGateway(path, routes, routeImports)
+	if err != nil {
+		emitError(err)
+		log.Fatal(err)
+	}
+
+	files = append(files, f)
+
+	f, err = generatePolicy(path, policies)
+	if err != nil {
+		emitError(err)
+		log.Fatal(err)
+	}
+
+	files = append(files, f)
+
+	f, err = generateDeadlines(path, timeouts)
+	if err != nil {
+		emitError(err)
+		log.Fatal(err)
+	}
+
+	files = append(files, f)
 	emitFiles(files)
 }
 
@@ -217,6 +321,156 @@ func generateMethod(basePath string, m grpcMethod) ([]*plugin.CodeGeneratorRespo
 	return files, err
 }
 
+// generateGateway renders the gateway routes for every annotated method.
+// Unlike method stubs it is regenerated every time, as it mirrors the proto
+func generateGateway(basePath string, routes []gatewayRoute, imports []string) (*plugin.CodeGeneratorResponse_File, error) {
+	path := filepath.Join(basePath, "gateway.lile.go")
+	gf := gatewayFile{Routes: routes}
+
+	if len(routes) > 0 {
+		gf.Imports = DedupImports(imports...)
+	}
+
+	log.Printf("%s %s", color.GreenString("[Generating]"), path)
+	return render(path, "gateway.tmpl", gf)
+}
+
+// generatePolicy renders the authorization policy of every method with a
+// lile.auth option, it is regenerated every time like the gateway
+func generatePolicy(basePath string, policies []methodPolicy) (*plugin.CodeGeneratorResponse_File, error) {
+	path := filepath.Join(basePath, "policy.lile.go")
+
+	log.Printf("%s %s", color.GreenString("[Generating]"), path)
+	return render(path, "policy.tmpl", policies)
+}
+
+// authRule returns the lile.auth option of a method, or nil if it has none
+func authRule(method *descriptor.MethodDescriptorProto) *options.AuthRule {
+	if method.GetOptions() == nil {
+		return nil
+	}
+
+	ext, err := proto.GetExtension(method.GetOptions(), options.E_Auth)
+	if err != nil {
+		return nil
+	}
+
+	rule, _ := ext.(*options.AuthRule)
+	return rule
+}
+
+// generateDeadlines renders the timeout of every method with a
+// lile.timeout option, it is regenerated every time like the gateway
+func generateDeadlines(basePath string, timeouts []methodTimeout) (*plugin.CodeGeneratorResponse_File, error) {
+	path := filepath.Join(basePath, "deadlines.lile.go")
+
+	log.Printf("%s %s", color.GreenString("[Generating]"), path)
+	return render(path, "deadlines.tmpl", timeouts)
+}
+
+// methodTimeoutOption parses the lile.timeout option of a method, it
+// returns zero if the method has none
+func methodTimeoutOption(method *descriptor.MethodDescriptorProto) (time.Duration, error) {
+	if method.GetOptions() == nil {
+		return 0, nil
+	}
+
+	ext, err := proto.GetExtension(method.GetOptions(), options.E_Timeout)
+	if err != nil {
+		return 0, nil
+	}
+
+	s, ok := ext.(*string)
+	if !ok || *s == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lile.timeout %q: %v", *s, err)
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("lile.timeout %q must be positive", *s)
+	}
+
+	return d, nil
+}
+
+// goDuration formats a duration as a Go expression, e.g. 5 * time.Second
+func goDuration(d time.Duration) string {
+	for _, u := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	} {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
+	}
+
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
+
+// httpRules returns the google.api.http rule for a method and its
+// additional bindings
+func httpRules(method *descriptor.MethodDescriptorProto) []*annotations.HttpRule {
+	if method.GetOptions() == nil {
+		return nil
+	}
+
+	ext, err := proto.GetExtension(method.GetOptions(), annotations.E_Http)
+	if err != nil {
+		return nil
+	}
+
+	rule, ok := ext.(*annotations.HttpRule)
+	if !ok {
+		return nil
+	}
+
+	return append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+}
+
+func httpMethod(rule *annotations.HttpRule) string {
+	switch {
+	case rule.GetGet() != "":
+		return "GET"
+	case rule.GetPut() != "":
+		return "PUT"
+	case rule.GetPost() != "":
+		return "POST"
+	case rule.GetDelete() != "":
+		return "DELETE"
+	case rule.GetPatch() != "":
+		return "PATCH"
+	}
+
+	return strings.ToUpper(rule.GetCustom().GetKind())
+}
+
+func httpPattern(rule *annotations.HttpRule) string {
+	switch {
+	case rule.GetGet() != "":
+		return rule.GetGet()
+	case rule.GetPut() != "":
+		return rule.GetPut()
+	case rule.GetPost() != "":
+		return rule.GetPost()
+	case rule.GetDelete() != "":
+		return rule.GetDelete()
+	case rule.GetPatch() != "":
+		return rule.GetPatch()
+	}
+
+	return rule.GetCustom().GetPath()
+}
+
 func streamFromBool(streaming bool) string {
 	if streaming {
 		return "stream"
@@ -225,7 +479,7 @@ func streamFromBool(streaming bool) string {
 	return "unary"
 }
 
-func render(path, tmpl string, m grpcMethod) (*plugin.CodeGeneratorResponse_File, error) {
+func render(path, tmpl string, m interface{}) (*plugin.CodeGeneratorResponse_File, error) {
 	hfs, err := fs.New()
 	if err != nil {
 		return nil, err
@@ -234,6 +488,7 @@ func render(path, tmpl string, m grpcMethod) (*plugin.CodeGeneratorResponse_File
 	t := template.New(tmpl)
 	funcMap := template.FuncMap{
 		"dedupImports": DedupImports,
+		"goDuration":   goDuration,
 	}
 
 	t = t.Funcs(funcMap)
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	protodescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/api/annotations"
+
+	"github.com/lileio/lile/v2/options"
 )
 
 func TestGenerator(t *testing.T) {
@@ -42,7 +46,103 @@ func TestGenerator(t *testing.T) {
 	}
 
 	assert.Nil(t, res.Error)
-	assert.Equal(t, len(res.File), 10)
+	assert.Equal(t, len(res.File), 13)
+}
+
+func TestGeneratorGatewayRoutes(t *testing.T) {
+	file := stubFile()
+	opts := &protodescriptor.MethodOptions{}
+	err := proto.SetExtension(opts, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/examples/{id}"},
+		AdditionalBindings: []*annotations.HttpRule{
+			{Pattern: &annotations.HttpRule_Post{Post: "/v1/examples"}, Body: "*"},
+		},
+	})
+	assert.Nil(t, err)
+	file.Service[0].Method[0].Options = opts
+
+	gateway := generatedFile(t, file, "server/gateway.lile.go")
+	assert.Contains(t, gateway, `Pattern:     "/v1/examples/{id}"`)
+	assert.Contains(t, gateway, `GRPCMethod:  "/example.ExampleService/Example"`)
+	assert.Contains(t, gateway, `Method:      "POST"`)
+	assert.Contains(t, gateway, `return &example.ExampleMessage{}`)
+}
+
+func TestGeneratorAuthPolicy(t *testing.T) {
+	file := stubFile()
+
+	admin := &protodescriptor.MethodOptions{}
+	assert.Nil(t, proto.SetExtension(admin, options.E_Auth, &options.AuthRule{
+		Roles: []string{"admin", "owner"},
+	}))
+	file.Service[0].Method[0].Options = admin
+
+	public := &protodescriptor.MethodOptions{}
+	assert.Nil(t, proto.SetExtension(public, options.E_Auth, &options.AuthRule{Public: true}))
+	file.Service[0].Method[1].Options = public
+
+	policy := generatedFile(t, file, "server/policy.lile.go")
+	assert.Contains(t, policy, `"/example.ExampleService/Example": {
+		Roles: []string{"admin", "owner"},`)
+	assert.Contains(t, policy, `"/example.ExampleService/ExampleWithoutBindings": {
+		Public: true,`)
+}
+
+func TestGeneratorDeadlines(t *testing.T) {
+	file := stubFile()
+
+	opts := &protodescriptor.MethodOptions{}
+	assert.Nil(t, proto.SetExtension(opts, options.E_Timeout, proto.String("1500ms")))
+	file.Service[0].Method[0].Options = opts
+
+	deadlines := generatedFile(t, file, "server/deadlines.lile.go")
+	assert.Contains(t, deadlines, `"/example.ExampleService/Example": 1500 * time.Millisecond,`)
+}
+
+func TestGoDuration(t *testing.T) {
+	assert.Equal(t, "5 * time.Second", goDuration(5*time.Second))
+	assert.Equal(t, "90 * time.Second", goDuration(90*time.Second))
+	assert.Equal(t, "2 * time.Minute", goDuration(2*time.Minute))
+	assert.Equal(t, "1500 * time.Millisecond", goDuration(1500*time.Millisecond))
+}
+
+// generatedFile runs the generator on file and returns the content of the
+// generated file with the name
+func generatedFile(t *testing.T, file *protodescriptor.FileDescriptorProto, name string) string {
+	t.Helper()
+
+	req := &plugin.CodeGeneratorRequest{
+		FileToGenerate: []string{"example.proto"},
+		ProtoFile: []*protodescriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("empty.proto"),
+				Package: proto.String("google.protobuf"),
+				Options: &protodescriptor.FileOptions{
+					GoPackage: proto.String("github.com/golang/protobuf/ptypes/empty"),
+				},
+			},
+			file,
+		},
+	}
+
+	data, err := proto.Marshal(req)
+	assert.Nil(t, err)
+
+	w := &bytes.Buffer{}
+	gen(bytes.NewReader(data), w)
+
+	var res plugin.CodeGeneratorResponse
+	assert.Nil(t, proto.Unmarshal(w.Bytes(), &res))
+	assert.Nil(t, res.Error)
+
+	for _, f := range res.File {
+		if f.GetName() == name {
+			return f.GetContent()
+		}
+	}
+
+	t.Fatalf("%s wasn't generated", name)
+	return ""
 }
 
 func stubFile() *protodescriptor.FileDescriptorProto {
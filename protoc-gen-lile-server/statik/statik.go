@@ -8,6 +8,6 @@ import (
 )
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x12\x00	\x00stream_stream.tmplUT\x05\x00\x01\xe0a\xed\\\x8c\x90M\x8b\xdb0\x10\x86\xcf\x9a_1\x15\xb4\xd84(\xf7\x82o\xfd\xa0\x97$4=\x16\x8a\xd7\x1e'\"\x91\xe4\x8cd\x87 \xf4\xdf\x17K	a\xd9\xcb\x9e\x06\xde\x8fG/\x1a\xdb\xee\xd4\x1e\x08=\xf1L\x0c\xa0\xcd\xe88`\x05B\x12\xb3c/\x01Q\x0e&\xe4\xab\x9d\x04@\x8c\x11{\xea\xa7\xf1w\x0e{T\xbf\xdc\xee\xceQ\xdb)\x8cS(\x0e\xa6\x045\xc00\xd9\x0e+\xbf\xd4\xd4\x9ex\xd6\x1dmZC\x98\xd2>\xbfZg\xe7.U>0\xb5\x06cT\x05\xb2\xe8)\xa9\xf7\xe5\xff1\xaab>0y1F\x10C9\x82\xe9\xb2ZT\xfc\xd6`\xe1\xaa?\xd4\xcdU\x0dB\xe8!;M\x83\xda\xa9\x1f\xdb\x9f\xb9 ^\x98\xda\x13\x08\x91\xe0\x19\xf9\xd4\xa0\xd5\xe7\xe23\x85\x89\xed\"?B\xeb5~w\xe8\x9d\xa1p\xd4\xf6\x80\x93\xa7a:\xe3U\x87#2]\n\x86\xe9\xf2\x063\x98\xa0v\xacm\x18*\xb9x\x0d~\xfe:\xff\xb3r\xb5$\xeb\x82F\xc4\xb2\xf01}O\xb6\xaf\xbe\xc4\xb8\xfc\xf1\xdf\xdbH)\xc5T\x7fhf^\xfa\xd4\x1c{\xb5\xa1k%\xad\x0bx\xa3\x80\xda\x8cg2d\x03\xf5\xb2\x86\x04\xaf\x01\x00\x00\xff\xffPK\x07\x08\x12	\xef\xe40\x01\x00\x00\x16\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x17\x00	\x00stream_stream_test.tmplUT\x05\x00\x01\xe0a\xed\\t\x8e\xc1j\xf30\x10\x84\xcf\xda\xa7\xd8_\x87\x1f\xbb\x14\xe5^\xf0\xa5\xa5\x85\\Rh\xf3\x02\x8e\xbcvDl\xc9\x91\xd6!A\xe8\xdd\x8be\xb7\x85\xd2\x9eg\xbe\xf9f\xac\xf5\xa9\xee\x08\x03\xf9\x0by\x003\x8c\xce3\x16\x80(\x8d\x93 $S`c;	 \xa4v\x96\xe9\xca\x12\x10c\xc4\x86\x9ai\xdcf \xa0\xec\x0c\x1f\xa7\x83\xd2n\xd8\x04\xf6\xc4\xfa\xe87\x99mo\x9b:\x04\xf2,Qm\xed8\xf1\x82`JP\x02\xb4\x93\xd5\xb8\xa7\xc01\xa2\xda\xd5\x03aJ\x05\xe3\xdd\xaaU\xfb\x12#\x08\xcdW|\xa8p\xf5\xab\xc7Z\x9f:\xef&\xdb\x14% \xce\xbez\xb8G\xf2>\xb7z\xa3b\xccc)\x15\x9a\xaf%\x88\xe5\x81\xda\x99\xbe\xe0\\,\x01\x10=\x9dg\xe0\x7f\x8cjk\xf7\xb7\x91R\x8a	\xc4\xb2\xa7\xde\xc96\x85\xa7s	b^\xaeV\x8fz\xea]\xa0\x1c\xfe1,Z\xe7\xe7\xd7\xc2S\xf8z\xb5\xc2o\xa4/3'L\x9b\x93\xaaB\xe3\xd4\xf3\xebK\x06\xc4\xc1S}\x02!\x12|W\xfeUhM\xbf\xe4\xbf\xe8~R\x9f\x15\xc7k\xcbS(A$H\xf0\x11\x00\x00\xff\xffPK\x07\x08\x89.1q#\x01\x00\x00\xee\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x00stream_unary.tmplUT\x05\x00\x01\xe0a\xed\\d\x8fO\x8b\xdb0\x10\xc5\xcf\x9aO1\x15\xb4\xd84(\xf7\x82\x0f\xa5\xff\xe8%	M\x8f\x85b\xecq\"\xd6\x92\x9c\x91\xe4\x10\x84\xbe\xfb\";a\x97]]F\xbc7\xf3\x9b7S\xdb=\xb5'BO<\x13\x03h39\x0eX\x81\x90\xc4\xec\xd8K@\x94\x83	K\xd5N\x02 \xa6\x84=\xf5q\xfa\xbd4{T\xbf\xdc\xe1\xceQ\xfb\x18\xa6\x18V\x07s\x86\x1a`\x88\xb6\xc3\xca\x971u$\x9euG\xbb\xd6\x10\xe6|\\\xb6\xd6\x8bs\x97*\x1f\x98Z\x83)\xa9\x15R\xf4\x9c\xd5\xfb\xe1\xff)\xa9\xd5|`\x96\xc4\x98@\x0ck\x11L\x97MQ\xf1K\x83+W\xfd\xa1n\xaej\x00!\xb6[\xfc\xee\xd0;C\xe1\xac\xed	\xa3\xa7!\x8ex\xd5\xe1\x8cL\x17\x10B\x0f\xe5\x83\x1f\x1a\xb4z\\\x80b0A\x1dX\xdb0T\xb2x\x0d~\xfc<\xff\xb3rS:k\x10\"\xc3:X\xb66\x0dj\xa7~\xec\x7fb\x02\\\x1eS\x88l\x1fY\x8ed\xfb\xaf\xb6\xff6:O\xd5\xa7r\xe1>\x86\xbf\xb7\xa9\\\x97\xf2[\xda\xeb\x18w\x0e1?\x9a^\x14\xc7^\xed\xe8ZI\xeb\x02\xde(\xa06\xd3H\x86l\xa0^\xd6 2dx\x0e\x00\x00\xff\xffPK\x07\x08\x0dG\xdd\xef3\x01\x00\x00\xf7\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x16\x00	\x00stream_unary_test.tmplUT\x05\x00\x01\xe0a\xed\\l\x8e\xb1n\xeb0\x0cEg\xf1+\x08\x0d\x0f\xd2C\xa1\xec\x05:\xb4\x9d\xb2dh\xf3\x03\xaa\xcc8Fl\xc9\xa1\xe8\xc0\x81\xa0\x7f/b\x1b\x99\xba^\xde{\x0eG\x1f.\xbe%\xcc\xc47b\x80n\x18\x13\x0b\x1aPZ(K\x17[\x0d\xa0tHQh\x16\x0d\x88\xa5`C\xcd4\xee\x97fF\xddvr\x9e~\\H\xc3.\x0b\x93\x843\xef\x96\xed\xe9\xbe\xf39\x13\x8bF\xb7\x8f\xe3$\xeb\x04k\x05\x0bp\x9ab\xc0#e)\x05\xdd\xc1\x0f\x84\xb5\x1a\xc1\xff\x9b\xd6\x1d-\x16PAf|}\xc3\xcd\xef>|\xb8\xb4\x9c\xa6\xd8\x18\x0b\x88\x0f\x9f\x1f^\x90\x98\x97V\xdf\xb9R\x16X\xad&\xc8lA\xad\x1f\xb8C\xd7\x1bY\x8a\x16\x00\x91\xe9\xfa\x18\xfc+\xc5\xed\xe3\xf1>R\xad\xa5\x82Zy\xee\x9bbc\x98\xae\x16\x14S~\xe2\xb7\xebg\x9f2\xbd\xc7\xe6\x8b\xc2\xcdX\xf8S\xf1\xcc\x92l1S\xb6P\xe17\x00\x00\xff\xffPK\x07\x08\xff\x9c	\xd3\xf2\x00\x00\x00p\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x00unary_stream.tmplUT\x05\x00\x01\xe0a\xed\\d\x8f\xc1j\xc30\x0c\x86\xcf\xd5S\xfc\xcba$c\xf8\x01\x06=\x8f^\xbaAv\x1f!QGX\xe3\x18\xd9\xee(F\xef>\xe4t\xf4\xb0\xa3\xfeO\xfe~+\x0c\xe3\xf7\xf0\xc5\x88,\x17\x16\xa2y	\xab$\xb4\xb4kXd\x95\xd8\x10P\n&\x9er8T\x18\xe1^\xd7\xf7\xdb;w\xf0!\xa7\x0d\xc0\xbd\xe5t\x9fT\xa9#:e?\xa2\x8d\xe6p=\xcbe\x1e\xf98,\x0c\xd5\xbeVv\x95\xdc\xa2V\xf0d\xe3\xc1\x7f\\\x83\x05\xcf\x88IxXP\x8a\xdb\xb4\xb6\xa9\xea\xfe\xeb>Kq\x1b\xfc\x13\xd7\x03P\x08\x10\x8ex\xd9\xe3\xb1\x14\xfb\xa2\xb9U\x8b\x12l\xc5\xc0V\xe2z\xf6S+\x1c;\x02\xe6S\x85\x0f{\xf8\xf9\\%\xa6IY\xbc\xe5\x04(\xd1\xee\x1e\xac\x12\xdd\x91\x7f\xda\xc6\xaf	WN\x98\x97p\xe6\x85}\xe2\xa9\xe9H\xe97\x00\x00\xff\xffPK\x07\x08\x9c\x0cu\xc5\xdf\x00\x00\x00i\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x16\x00	\x00unary_stream_test.tmplUT\x05\x00\x01\xe0a\xed\\l\x8e\xcdj\xeb0\x10\x85\xd7\x9a\xa7\x98\xeb\xc5\xc5*A\xd9\x17\xbc)$\x90M\n%/\xa0(cG$\xb6\xdc\xd18$\x08\xbd{\xf1\x0f\xc9\xa2\xdd\xce\x99\xf3}\xa7\xb7\xeeb\x1b\xc2H|#\x06\xf0m\x1fX\xb0\x04U\x08E\xf1]S\x00b\xe1C\x01\xa0\n\x17:\xa1\xbb\x8c\xa7\x94\xf0D\xa7\xa1\xdfM\x85\x88E\xe3\xe5<\x1c\x8d\x0b\xed:\n\x93\xb83\xaf'D\xfdX\xdb\x18\x89\xa5@\xb3\xeb\xfaA\xe6\n\xe6\x0c\x1a\xa0\x1e:\x87\x07\x8a\x92\x12\x9a\xbdm	s.\x05\xdf\x16\xbb9hL\xa0\x9c\xdc\xf1\xbd\xc2\xc5o>\xac\xbb4\x1c\x86\xeeTj@d\xfa\x1e\xd3\xff#b\xd7\x1d\x1e\xfd\x08I\x19\x00q\x9cb\xdb\x15\x12\xf3\x04\xb8z\x93\xd2\xe4\xc9\xb9tr_\x8de\x0dj^h\xf6\xfeZ\xca\xf4\xad\x01T\x1dx\x94+\xa6\xf8$\xcc@\xf3E\xeeVjP\xca\xd7SRU\xe8\x83\xd9|n\xa7\x82:2\xd9\x0b(\x95\xe1\xf5\xf2\xaf\xc2\xce_\xe7|\xd1m\xed\xd3g6\xcc\x81K\xad\x7f\xd5\xff\x98\xf6:\x06Y\xeeLQ\x83\xca\x90\xe1'\x00\x00\xff\xffPK\x07\x08\xbeJO\x87\x1b\x01\x00\x00\xd3\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00unary_unary.tmplUT\x05\x00\x01\xe0a\xed\\D\x8eAj\xc30\x10E\xd7\x9eS|\xb4\x92J\xd0%\xba\xf2&Y\xa4\x170\xf2\xb4\x98\xc6\xb2\x18\x8d\xd2\x04\xa1\xbb\x97\xc8\x86\xac\x86\xff\xe6\xc1\xffi\n\xbf\xd3\x0f#\xb3\xdcY\x88\x965m\xa2\xb04\x18\x16\xd9$\x1b\xa2\xc1\x84-*?\xd4\xd0P+f\x9eK\x1a\xbb\x97\xe1\xc7\x98\x8a\xee	\xfeR\xf4\x9dZ#G\xf4]b\x80\xcd\xa8\x15\xfe\xcar_\x02\x9f\xa7\x95\xd1\xda\xb5W\xba\xfe9\x90\x0d\xfa\xc0Q\xe6?\xf7{\x82\xe0\xe3\xe5\x8c\xf1\xeb\x99^\x96\x83\xed\xe0R\xf4 '\xf4\xb1\x0e\x95\x06a-\x12\x11\x97\xdbA\xb3?\xf3\x9f5qS<Y\xb1\xac\xe9\xc6+G\xe5\xd98j\xf4\x1f\x00\x00\xff\xffPK\x07\x08\xb0d\x95&\xb3\x00\x00\x00\x02\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x00unary_unary_test.tmplUT\x05\x00\x01\xe0a\xed\\L\x8e1n\xc30\x0cEg\xf3\x14\x84\x86\xc2.\x02y/\xd0\xa5\x9b\x97L\xbe\x80+3\x8e\x90XR)\xaap \xe8\xee\x81\x15\x0fY\xf9\xff\xfb\x8fa2\xb7i!\x8c\xc4\xff\xc4\x00v\x0d\x9e\x05[h\x94P\x14\xeb\x16\x05\xd0(\xe3\x9d\xd0&\n\x10s\xc6\x99\xe6\x14\x86\xda\x8c\xa8\x16+\xd7\xf4\xab\x8d_\xfb(Lb\xae\xdcW\xf6\xf2\xe8\xa7\x18\x89E\xa1\x1e\\H\xf2B\xb0\x14\xe8\x00.\xc9\x19\x1c)J\xce\xa8\xcf\xd3JXJ+\xf8yh\xf5\xd8a\x86\xc6\xc8\x86_\xdfx\xf8\xf5\xcfdn\x0b\xfb\xe4\xe6\xb6\x03D\xa6\xbf=\xfd\xd8'\x067>\xc2>\x92\x0b\xd4(\x9e\x90\x98+}\xb7\xfa\xddbd;\xedl\x07\xcd\xebA}\xb6\xf7Vj\xff\xed\xe6\xe583\xc5\x0e\n<\x03\x00\x00\xff\xffPK\x07\x08o\x9c@\x0b\xce\x00\x00\x00,\x01\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x12	\xef\xe40\x01\x00\x00\x16\x02\x00\x00\x12\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00stream_stream.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x89.1q#\x01\x00\x00\xee\x01\x00\x00\x17\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81y\x01\x00\x00stream_stream_test.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x0dG\xdd\xef3\x01\x00\x00\xf7\x01\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xea\x02\x00\x00stream_unary.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\xff\x9c	\xd3\xf2\x00\x00\x00p\x01\x00\x00\x16\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81e\x04\x00\x00stream_unary_test.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x9c\x0cu\xc5\xdf\x00\x00\x00i\x01\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa4\x05\x00\x00unary_stream.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\xbeJO\x87\x1b\x01\x00\x00\xd3\x01\x00\x00\x16\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcb\x06\x00\x00unary_stream_test.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\xb0d\x95&\xb3\x00\x00\x00\x02\x01\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x813\x08\x00\x00unary_unary.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcNo\x9c@\x0b\xce\x00\x00\x00,\x01\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81-	\x00\x00unary_unary_test.tmplUT\x05\x00\x01\xe0a\xed\\PK\x05\x06\x00\x00\x00\x00\x08\x00\x08\x00T\x02\x00\x00G\n\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00deadlines.tmplUT\x05\x00\x01\x80Cm8\\\x8fAK\x031\x10\x85\xcf\x9d_\xf1\xd8\x93B7{\x17<\xb5\"\x1e\xb4\"\xbd\x89\x87\xd8L\xd3\xe0&\xb3$\xd9\x82\x84\xfcw\xc9\xd6\x82x~\xef\xfb\xe6\xcd0`#\x86a9p\xd4\x99\x0d>\xbf1E\xc9r\xe8-\x87~t#\xf7\x89\xe3\x99\xa3\xc2v\x87\x97\xdd\x1e\x0f\xdb\xa7\xbd\"\x9a\xf4\xe1K[\xc6%%r~\x92\x98qC\xab.;\xcf\x1d\xdd\x12\x0d\x03\x9e9\x9f\xc4\xec\x9dg\x99s\x82\x8e\x8c|\xbaRH\xce0\x0ck3\xba\xc0	r\x84_\xfai\xfdg\xd21\x8ao\xaa\xc6\xb5A\xaa\xf9e\xce\x90);		.,\xd9\xb2\x1b\x86\x8f.\xb8\x16\xd0Y\xc7\xff\xf7\xef\xe1\xf5\xf4\x9ert\xc1~4\x8f\xda\xceQ\xb7v\xa1RzD\x1d,C\xa1VZu\xa5@=\xbe\xbdn.\x0e\xd4\xda\xdd\xa1\x14X\xb92P\xbf\x8f\xa1\xd6\xf5\xc2s0\xa8\x95*\xfd\x0c\x00PK\x07\x08\xfai47\xe6\x00\x00\x00Z\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c\x00	\x00gateway.tmplUT\x05\x00\x01\x80Cm8\x8cR\xc1\x8e\xda0\x14<\xc7_1\xe2P\xedJ\xe0H=\"\xf5R\xb6\xdaRi\x01\xd1\xdc\xaa\x1e\x0cy1V\x89\x9d\xda/\xacP\xe4\x7f\xaf\xec\xc0.\xb4\x97\xe6\x92hf<3\xef\xc5e\x89\x85\xab	\x9a,y\xc5TcwF\xe7\x1d\xbb\xfdL\x93\x9d\x1d\xcd\x91f\x81\xfc\x89\xbc\xc4\xd3\x1a\xabu\x85/O\xcbJ\n\xd1\xa9\xfd/\xa5	#+\x84i;\xe7\x19\x0fb\x18f0\x0d\xe4\xd6\xf5L\x011\x8ab\xa2\x0d\x1f\xfa\x9d\xdc\xbb\xb6\xd4\xee\xa8\xac.s\xc8\xaeo\xc6\x8fI>E\xb6\xfeG\x9e\x1a\x18\x97_\xe5\xe9c\xd2A.sT\xb6~\x14\xa2,\xf1\xac\x98^\xd5\xf9\x92\xd8\xaa.\xe0kUm\xcao\xdf\xd7+x\xfa\xddS\xe0\x00v\xd0\xdb\xcd\x02-\xf1\xc1\xd5az3u\xe3]\x0b>P2\xd3\xce\xe9#I\xd5\x19y`\xee\xa0\xacu\xac\xd88\x1b`lR\x8d\x1bBM\x8d\xb1&\x11\xe2\xa4\xfc_%>\xe1\xc7\xcfTZ\xde\xc2C\x1e\xd3+\xab\xe9n?\x83(\x8a\x97\xdcj\x8e\xfcL\x86\x01rD\x10\xe3d*\x8ab\xa3\x98\xc9\xdb\xf9;\x7fA\xae\x82\xcf\xae>_\x8e_\x04	\xb9\xb2\xcf\xdb\xcd\xe2-\"\xdb\xbf#W\xcd\x8a^\xb7\xe3\xb2\xe6@\xd3\xdb\xfd\xc3\xe38\xaa|\xa1\x10\xd2\xcf\x1e\xe0\x89{o\xf1!9,mu\xee\x081\x0e\x11\xf1\xcd t\xce\x06\x9a\xff\x8f\xc1\xba\xe7{\x878\xbd\xbd\x08Q\xfc\x19\x00PK\x07\x08\xe8PS\xaam\x01\x00\x00\xa0\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00policy.tmplUT\x05\x00\x01\x80Cm8T\x8eO\x8b\xdb0\x10\xc5\xcf\xd6\xa7x\x98\x14Z\xf0\x1f\xe8\xd1\x90CIJ\xe9\xa1M\x08\xb9\x95\x1e\x14{l\x0f+K^Y\xce\x12\x84\xbe\xfb\"{\xd9$'\x8d\xe6\xbd\xf7\x9bW\x96\xd8\x99\x86\xd0\x91&+\x1d5\xb8\xdc0Z\xe3L\x9dw\xa4s\xc5\x8a\xf2\x89\xec\x95l\x81\xfd\x01\x7f\x0fg\xfc\xdc\xff>\x17B\x8c\xb2~\x91\x1daU\x85\xe0a4\xd6\xe1\xabH\xd2\x8e]?_\x8a\xda\x0ce\x04\xb0Y\x9e\xf2\xfa=\x15\xdf\x84(K\xfc\x98]\x7f4\x8a\xeb\x1b\x14On\xc2[o0\xc8\x1bj\xa9\x14H\xd6=\x06r\xbdi\xb2\x87f\xad5\x03\\O\x88\xb0B\xce\xae\x8f(3:6z\x02\xebE[\xba\xa3\xa1\x965GA\\\xa5}<\xb7]\xd3\xf7\x8d\x17\xde\xe7\xb0Rw\x84\x02!\x88$\xf5\x1e\xc5\xaf\xd3q\xf7g\xa9\x80\x10\xd2\n\xab\x8d[\x14'\xa3hZ\x8c\xc92V\xc0\xbf\xff\x93\xb3\xac;\x8f;k\xc3\x196\x16\xd5\xf6\x9e\xf0>\x026\x8c\x102x\x0f\xd2\x11\xee=F\xcb\xda\xb5H\xbf\xbc\xa61\x13\xc2\x87\x98\x87\x80\x90-\x97\xe37\x84\xcf\x12\xc7\xf9\xa2\xb8\x8e\x9b$Y\xe7\n\xce\xce\xf4dN\x9e\xb3A\xbc\x0f\x00PK\x07\x08\x90\xc5\x87\xfa1\x01\x00\x00\xee\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x12\x00	\x00stream_stream.tmplUT\x05\x00\x01\x80Cm8|R\xc1\xae\xd30\x10<\xdb_\xb1X\x02%\xa2r\xeeH\xb9\xf1@\\x\x15\x85\x1b\x12\n\xc9\xc6\xb5\x1a\xdb\xe9\xda\x0e\xaa,\xff;r\xd2\xd2\"\xc4;\xad<\xb33\xbb\x1e{\xee\xfaS\xa7\x10<\xd2\x82\xc4\xb96\xb3\xa3\x00\x15gB9\xa7&\x94\xcaM\x9dU\xd2\x91j\x14\xcd}\xd3\xbb\x01\xbd\xf8?\xefC\x17\xa2\x17\x1c@\x8c&\xacU;\xc19@J0\xe0\x10\xe7O\xeb\x0c\x0f\xf2\xa3\xdb_\xc7\xcb\xe7\x18\xe6\x186\x06r\xe65\xe7c\xb4=T\xbe\xc8\xe4\x01i\xd1=~\xee\x0cB\xce\xe5\x84T\xaf\xcc\x15\xaa| \xec\x0c\xa4$7\x93\x82\xe7,\xff\x15\xffHi\x15\xddm\x90\xc8\x11$\xce\xc6\xad0\xc2\xf3\xae\xa0\xf0\xae\x85\xcdW~\xc1~\xa9j\xce\x98\x1eW\xa6mA;\xf9\xf4\xfc\xa1\xe8\x18\xfbI\xd8\x9d8c\x99\xdf[^\xb5`\xf5\xb4\xf1\x84!\x92-\xca[S\xd3\xc0{\x07\xde\x19\x0cGm\x15D\x8fc\x9c\xe0\x97\x0eG <o6\x84\xe7\xbflF\x13\xe4\x9e\xb4\x0dc%\n\xd7\xc2\xeb\xb7\xcbw+v\xa5\xb3l\x97K\xceP\xe6\xc0\x9f\xd5\x0fh\x87\xeaMJ%\xe3\xaf\x97\x19sN\xf9\xe1&/\xacY\xecn\xd8\xf6\xac\xf2\xa9dU\xad\x7f@~\xb3\xda\xcc\x13\x1a\xb4\x01\x87\x1d\x08\xeb\x02\\0\xc0\x03*j\x9e\xf9\xef\x01\x00PK\x07\x08p|\xf7\xdaS\x01\x00\x00d\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x17\x00	\x00stream_stream_test.tmplUT\x05\x00\x01\x80Cm8t\x8f\xcdj\xeb0\x10\x85\xd7\x9a\xa7\x98\xab\xc5\xc5.E\xd9\x17\xbcii!\x9b\x14\xda\xbc\x80#\x8f\x1d\x11[r\xa4qH\x10z\xf7b\xd9m\xa1\xb4\xebs\xbe\xf33\xd6\xfaTw\x84\x81\xfc\x85<\x80\x19F\xe7\x19\x0b@\x94\xc6I\x10\x92)\xb0\xb1\x9d\x04\x10R;\xcbte	\x881bC\xcd4n3\x10Pv\x86\x8f\xd3Ai7l\x02{b}\xf4\x9b\xcc\xb6\xb7M\x1d\x02y\x96\xa8\xb6v\x9cxA0%(\x01\xda\xc9j\xdcS\xe0\x18Q\xed\xea\x810\xa5\x82\xf1n\xadU\xfb\x12#\x08\xcdW|\xa8p\xedW\x8f\xb5>u\xdeM\xb6)J@\x9c\xfb\xea\xe1\x1e\xc9\xfb\xec\xea\x8d\x8a1\x87\xa5Th\xbe\x96 \x96\x05jg\xfa\x82\xb3\xb1\x04@\xf4t\x9e\x81\xff1\xaa\xad\xdd\xdfFJ)&\x10K\x9ez'\xdb\x14\x9e\xce%\x889\xb9Z{\xd4S\xef\x02e\xf1\x8f`\xd1:?\xaf\x16\x9e\xc2\xd7\xaa\x15~#}\x999a\xda\xacT\x15\x1a\xa7\x9e__2 \x0e\x9e\xea\x13\x08\x91\xe0\xdb\xf2\xafBk\xfaE\xff\xe5\xc7O\xea\xd3\xe2x}\xeb)\x94 \x12$\xf8\x18\x00PK\x07\x08\x89.1q!\x01\x00\x00\xee\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x00stream_unary.tmplUT\x05\x00\x01\x80Cm8t\x91\xc1\x8e\xd40\x0c\x86\xcf\xf1S\x98H\xa0V\x8c2w\xa4\x1e\x10,\x88\x0b\xb3b\xe1\x86\x84\xaa\xd6\xedF4I\xd7q\x06\xad\xa2\xbc;J;\x0b#\xd0\xf6\xe2\xf6\xff\xed\xcf\xae\xbd\xf6\xc3\xcf~&\x8c\xc4gb\x00\xeb\xd6\xc0\x82\x0d(=\x870/d\xe6\xb0\xf4~6\x81\xe7\xe3\xcc\xebp\x1c\xc2HQ?\xefG\xe9%E\x0d\x88zr\xb2E\x1b4\x00b\xce8\xd2\x98\xd6O[\x8f\x88\xe6c\xb8\xbd\xb47\xa7$k\x92\xdd\xc1R\xa0\x05\x98\x92\x1f\xb0\x89\xb5\xcc\xdc\x11\x9f\xed@\x9f{GXJ\xfd\"n7\xe7\"5Q\x98z\x879\x9b\x1dR\xf5R\xcc\xff\xc5?r\xde\x8a\xfeb\x8890fP\xd3\x1e\x14\xd3\xc3\xa1\xaa\xf8\xa6\xc3\x9dk\xbe\xd0pnZ\x00\xa5\x8eG|\x1f0\x06Gro\xfd\x8c)\xd2\x94\x16\xfce\xe5\x1e\x99\x1e@);\xd5\x17|\xd1\xa1\xb7K\xe5*591\xb7l\xbdL\x8d\xae^\x87/_\x9f\xbf{}\xa8\x99-(U*\xdaN[\xd7\xaeC\x1b\xcc\xcd\xe9\x03f\xc0\xeda\x92\xc4\xfei\x96;\xf2\xe3[?\xbe[B\xa4\xe6U\xfd\xc3S\x92\xaf\x8fk\xddC.\xff\xd2\xae\xc7\xb8p\x88\xf9)\xe9\x0f\xb9\x1e\xcd\xdc\xd4M4\xdb\x85\xcd7o\xdd\xba\x90#/4\x1eP\xfb \xf8H\x82W\xaanA\x15(\xf0{\x00PK\x07\x08\xb6P\xb2bT\x01\x00\x00E\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x16\x00	\x00stream_unary_test.tmplUT\x05\x00\x01\x80Cm8l\x8e1n\xf30\x0cFg\xf1\x14\x84\x86\x1f\xd2\x8fB\xd9\x0bth;e\xc9\xd0\xe6\x02\xaa\xcc8Fl\xc9\xa1\xe8\xc0\x81\xa0\xbb\x17\xb1\x8dL]\xa5\xef\xbd\xc7\xd1\x87\x8bo	3\xf1\x8d\x18\xa0\x1b\xc6\xc4\x82\x06\x94\x16\xca\xd2\xc5V\x03(\x1dR\x14\x9aE\x03b)\xd8P3\x8d\xfbe\x99Q\xb7\x9d\x9c\xa7\x1f\x17\xd2\xb0\xcb\xc2$\xe1\xcc\xbb\x85=\xddw>gb\xd1\xe8\xf6q\x9cdE\xb0V\xb0\x00\xa7)\x06<R\x96R\xd0\x1d\xfc@X\xab\x11\xfc\xbfe\xdd\xd1b\x01\x15d\xc6\xd77\xdc\xfa\xee\xc3\x87K\xcbi\x8a\x8d\xb1\x80\xf8\xe8\xf9\xe1\x05\x89yY\xf5\x9d+e\x91\xd5j\x82\xcc\x16\xd4z\x81;t\xbd\x91eh\x01\x10\x99\xae\x0f\xe0_)n\x1f\x8f\xf7\x91j-\x15\xd4\xeas\xdf\x14\x1b\xc3t\xb5\xa0\x98\xf2S\xbf\xfd~\xf6)\xd3{l\xbe(\xdc\x8c\x85?\x13\xcf\xb7$[\x99)[\xa8\xf0;\x00PK\x07\x08\xff\x9c	\xd3\xef\x00\x00\x00p\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x00unary_stream.tmplUT\x05\x00\x01\x80Cm8t\x90\xc1j\xf30\x10\x84\xcf\xd9\xa7\x98\xdf\x87\x1f\xbb\x04\xe5^\xc8\xb1\x94\\\xdaB\xdas1\xf6F\x98\xda\x92X\xc9\x81 \xf4\xeeemC\n\xa5\xc7\x99O|#6\xb4\xddWk\x19\x91\xe5\xcaB4L\xc1KBM\xbb\xcazoG6\xd6\x8f\xad\xb3\xc6\x8b=X	\xdd\xa1\xf3=\xc7\xeao\x1eS\x9b\xe6X\x11\x903z\xee\xe7pZ\x9c\x11\xe6\xd9\xbfms\xe6\xe4\xc2\x9cV\x00\xf3:\xa7{*\x85\x1a\xa2\xcb\xec:\xd4Q\x1d\xe6\xccr\x1d:~i'F)\x9aX\x9a\x85lU-x\xd0xr\xef\xb7\xa0\xc5\x1e1	\xb7\x13r6\xabV_\x96b~\xeb>s^4w1\x8bxA&@8\xe2\xf1\x88\xff9\xeb\x17\xd5]J.\x04}\xa2`\x1d1gv}-\x1c\x1b\x02\x86\xcb\x02\xff\x1d\xe1\x86q\x91\xa8&\xcd\xe2\xb4'\xa0\x10\xed\xb6b\xbd\x94y\xd2\xbdz9\xab\xf9p\xc3\x14F\x9e\xd8%\xee\xf7\xa8\x9cO\xb8q\xc2\x8f\xb6j\xa8\xd0\xf7\x00PK\x07\x08Vu^M\xfd\x00\x00\x00\xb7\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x16\x00	\x00unary_stream_test.tmplUT\x05\x00\x01\x80Cm8l\x8eA\xca\xdb0\x10\x85\xd7\x9aSL\xbd(V	\xca\xbe\xe0M!\x81lR(\xb9\x80\xa2\x8c\x1d\x91\xd8rG\xe3\x90 t\xf7\"\xdb$\x8b\xfe\xdb\x99\xf7\xbe\xef\x8d\xd6\xddlG\x18\x89\x1f\xc4\x00\xbe\x1f\x03\x0b\xd6\xa0*\xa1(~\xe8*@\xac|\xa8\x00T\xe5\xc2 \xf4\x94rJ	/t\x99\xc6\xc3\\\x88Xu^\xae\xd3\xd9\xb8\xd0o\xa30\x89\xbb\xf2vF\xb4\xaf\xad\x8d\x91X*4\x87a\x9cd\xa9`\xce\xa0\x01\xdaipx\xa2()\xa19\xda\x9e0\xe7Z\xf0\xc7j7'\x8d	\x94\x93'\xfelp\xf5\x9b_\xd6\xdd:\x0e\xd3p\xa95 2\xfd-\xdf\xef\x05q\x18N\xaf\xb1@R\x06@,Sl\xbfAb.\x11w\xf7&\xa5\xd9\x93s\xed\xe4\xb9)e\x0djYh\x8e\xfe^\xcb\x9c\xd6\x00\xaa\x0d\\\xe4\x8a)\xbe	\x0b\xd0\xfc!\xf7\xa85(\xe5\xdb\xf9\xd34\xe8\x83\xd9\xfd\xde\xcf\x05uf\xb27P*\xc3'\xf2\xad\xc1\xc1\xdf\x97\xff\xaa\xdb\xdb\xb7\xcf\xec\x98\x03\xd7Z\xffW\xffb\xda\xe7\x18d\x9d\xcc\x145\xa8\x0c\x19\xfe\x0d\x00PK\x07\x08\xbeJO\x87\x19\x01\x00\x00\xd3\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00unary_unary.tmplUT\x05\x00\x01\x80Cm8t\x8f\xc1j\xc40\x0cD\xcf\xd1W\x08\x9f\x92\x12\xbc\x1fQz\xc8\xa5{h\xfb\x01\xc1QMh\"\x1bY^v	\xfe\xf7\xe2$\xd0^\xf6$\xe6\xcd0b\xe2\xe8~FO\x98Hn$\x00\xf3\x1a\x83(\xb6\xd0\x18\x1f\x82_\xc8\xfa\xb0\x8c\xecm\x10\x7f\xf1\x12\xdd\xc5\x85\x89\x92y\xee'\x1d5'\x03\xd0\x18\x17X\xe9\xae\x06\x9am\xc3\x89\xa6\x1c\x87\xbd>\xa1\x1d8f=\x14\xdak\xd6?U\nt\x00\xdf\x99\x1d\xb6	\xb7\x0d\xed\x07\xc9mv\xf4>\xae\x84\xa5TE\xd2\xed\xce\x89Z\xa7w<\x9f\xd9\xd7\xe3\xf6(\xf8R3\x03\x7f>bMu\xd8\xee\xe0\x9a\xf5$=\x92H\xa8]\xd0\x08i\x16F\x9e\x97\x1e\x8f	\xf6\xad\x9a\xed\xbe\xd7~\xf1\xbc\xc6\x85Vb\xa5\xa9G\xc3A\xf1A\x8a\xff\xa8\xe9\xa0\xc0\xef\x00PK\x07\x08\xef|\xf8\xf8\xd2\x00\x00\x00P\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x00unary_unary_test.tmplUT\x05\x00\x01\x80Cm8L\x8e1n\xc30\x0cEg\xf3\x14\x84\x86\xc2.\x02y/\xd0\xa5\x9b\x97L\xbe\x80+3\x8e\x90XR)\xaap \xe8\xee\x81\x1c\x0fY\xf9\xff\xfb\x8fa2\xb7i!\x8c\xc4\xff\xc4\x00v\x0d\x9e\x05[h\x94P\x14\xeb\x16\x05\xd0(\xe3\x9d\xd0&\n\x10s\xc6\x99\xe6\x14\x86\xbd\x19Q-V\xae\xe9W\x1b\xbf\xf6Q\x98\xc4\\\xb9\xdf\xd9\xcb\xa3\x9fb$\x16\x85zp!\xc9\x0b\xc1R\xa0\x03\xb8$gp\xa4(9\xa3>O+a)\xad\xe0\xe7\xa1\xd5c\x87\x19\x1a#\x1b~}\xe3\xe1\xd7?\x93\xb9-\xec\x93\x9b\xdb\x0e\x10\x99\xfej\xfaQ'\x067>B\x1d\xc9\x05\xf6(\x9e\x90\x98kn\xeeV\xbf[\x8cl\xa7\xcav\xd0\xbc\x1e\xd4g{oe\xef\xbf\xdd\xbc\x1cg\xa6\xd8A\x81\xe7\x00PK\x07\x08o\x9c@\x0b\xcb\x00\x00\x00,\x01\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xfai47\xe6\x00\x00\x00Z\x01\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00deadlines.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xe8PS\xaam\x01\x00\x00\xa0\x02\x00\x00\x0c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81+\x01\x00\x00gateway.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x90\xc5\x87\xfa1\x01\x00\x00\xee\x01\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdb\x02\x00\x00policy.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(p|\xf7\xdaS\x01\x00\x00d\x02\x00\x00\x12\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81N\x04\x00\x00stream_stream.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x89.1q!\x01\x00\x00\xee\x01\x00\x00\x17\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xea\x05\x00\x00stream_stream_test.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xb6P\xb2bT\x01\x00\x00E\x02\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81Y\x07\x00\x00stream_unary.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xff\x9c	\xd3\xef\x00\x00\x00p\x01\x00\x00\x16\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xf5\x08\x00\x00stream_unary_test.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(Vu^M\xfd\x00\x00\x00\xb7\x01\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x811\n\x00\x00unary_stream.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xbeJO\x87\x19\x01\x00\x00\xd3\x01\x00\x00\x16\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81v\x0b\x00\x00unary_stream_test.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xef|\xf8\xf8\xd2\x00\x00\x00P\x01\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xdc\x0c\x00\x00unary_unary.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(o\x9c@\x0b\xcb\x00\x00\x00,\x01\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xf5\x0d\x00\x00unary_unary_test.tmplUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x0b\x00\x0b\x00\x1e\x03\x00\x00\x0c\x0f\x00\x00\x00\x00"
 	fs.Register(data)
 }
@@ -0,0 +1,323 @@
+package lile
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rateLimitAllowedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lile_rate_limit_allowed_total",
+		Help: "RPCs allowed by a rate limit.",
+	}, []string{"grpc_method"})
+
+	rateLimitRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lile_rate_limit_rejected_total",
+		Help: "RPCs rejected with ResourceExhausted by a rate limit.",
+	}, []string{"grpc_method"})
+)
+
+func init() {
+	prometheus.MustRegister(rateLimitAllowedTotal, rateLimitRejectedTotal)
+}
+
+const (
+	// rateLimitIdle is how long a caller's bucket is kept after its last
+	// call
+	rateLimitIdle = 10 * time.Minute
+
+	// rateLimitMaxHeaderBuckets is the default of RateLimiter.MaxHeaderBuckets
+	rateLimitMaxHeaderBuckets = 10000
+)
+
+// RateLimit is a token bucket that refills at Rate tokens a second and
+// holds up to Burst tokens, each call takes one token
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter limits calls per method and per caller. Each caller gets its
+// own bucket for each limited method
+type RateLimiter struct {
+	// Limits maps full method names, e.g. /pkg.Service/Method, to their
+	// limit. The "*" entry applies to every method without its own limit
+	Limits map[string]RateLimit
+
+	// Header names a metadata header, e.g. x-api-key, that identifies the
+	// caller. Each value gets its own bucket with a full burst, so it should
+	// be something callers can't mint freely, such as an API key checked by
+	// an Authenticator. Callers without it are identified by their peer IP,
+	// or for gateway calls the HTTP client's IP
+	Header string
+
+	// MaxHeaderBuckets caps the buckets kept for callers identified by
+	// Header, it defaults to 10000. Once reached, the least recently used
+	// bucket is dropped to make room for a new one
+	MaxHeaderBuckets int
+
+	mu        sync.Mutex
+	buckets   map[rateBucketKey]*rateBucket
+	headers   *list.List
+	lastSweep time.Time
+}
+
+type rateBucketKey struct {
+	method, caller string
+}
+
+type rateBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+	// elem is the bucket's entry in headers, for callers identified by
+	// Header
+	elem *list.Element
+}
+
+// NewRateLimiter creates a rate limiter with no limits
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{Limits: map[string]RateLimit{}}
+}
+
+// UnaryInterceptor rejects unary calls over their limit with
+// codes.ResourceExhausted and a RetryInfo detail
+func (l *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects streams over their limit like UnaryInterceptor,
+// a stream takes a single token when it is opened
+func (l *RateLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := l.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (l *RateLimiter) limitFor(method string) (RateLimit, bool) {
+	if lim, ok := l.Limits[method]; ok {
+		return lim, true
+	}
+
+	lim, ok := l.Limits["*"]
+	return lim, ok
+}
+
+func (l *RateLimiter) allow(ctx context.Context, method string) error {
+	lim, ok := l.limitFor(method)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	header, ip := l.callers(ctx)
+
+	l.mu.Lock()
+	if l.buckets == nil {
+		l.buckets = map[rateBucketKey]*rateBucket{}
+	}
+
+	l.sweep(now)
+	b := l.bucket(method, header, ip, lim)
+	b.lastSeen = now
+	l.mu.Unlock()
+
+	r := b.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		rateLimitRejectedTotal.WithLabelValues(method).Inc()
+		return status.Errorf(codes.ResourceExhausted, "rate limit for %s exceeded", method)
+	}
+
+	delay := r.DelayFrom(now)
+	if delay == 0 {
+		rateLimitAllowedTotal.WithLabelValues(method).Inc()
+		return nil
+	}
+
+	// Hand the token back, the caller is told when to retry instead
+	r.CancelAt(now)
+	rateLimitRejectedTotal.WithLabelValues(method).Inc()
+
+	st := status.Newf(codes.ResourceExhausted, "rate limit for %s exceeded", method)
+	if ds, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: ptypes.DurationProto(delay),
+	}); err == nil {
+		st = ds
+	}
+
+	return st.Err()
+}
+
+// sweep drops buckets that haven't been used recently, it must be called
+// with mu held
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+
+	l.lastSweep = now
+	for k, b := range l.buckets {
+		if now.Sub(b.lastSeen) > rateLimitIdle {
+			delete(l.buckets, k)
+			if b.elem != nil {
+				l.headers.Remove(b.elem)
+			}
+		}
+	}
+}
+
+// bucket returns the caller's bucket for the method, creating it if needed.
+// The caller is identified by header unless it's empty. It must be called
+// with mu held
+func (l *RateLimiter) bucket(method, header, ip string, lim RateLimit) *rateBucket {
+	if header == "" {
+		key := rateBucketKey{method: method, caller: ip}
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &rateBucket{limiter: rate.NewLimiter(rate.Limit(lim.Rate), lim.Burst)}
+			l.buckets[key] = b
+		}
+
+		return b
+	}
+
+	// Header buckets are kept most recently used first
+	if l.headers == nil {
+		l.headers = list.New()
+	}
+
+	key := rateBucketKey{method: method, caller: header}
+	if b, ok := l.buckets[key]; ok {
+		l.headers.MoveToFront(b.elem)
+		return b
+	}
+
+	max := l.MaxHeaderBuckets
+	if max <= 0 {
+		max = rateLimitMaxHeaderBuckets
+	}
+
+	for l.headers.Len() >= max {
+		oldest := l.headers.Back()
+		delete(l.buckets, oldest.Value.(rateBucketKey))
+		l.headers.Remove(oldest)
+	}
+
+	b := &rateBucket{limiter: rate.NewLimiter(rate.Limit(lim.Rate), lim.Burst)}
+	b.elem = l.headers.PushFront(key)
+	l.buckets[key] = b
+	return b
+}
+
+// callers returns the caller's configured header, if set, and peer IP
+func (l *RateLimiter) callers(ctx context.Context) (header, ip string) {
+	if l.Header != "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(l.Header); len(v) > 0 && v[0] != "" {
+				header = l.Header + ":" + v[0]
+			}
+		}
+	}
+
+	addr := peerAddr(ctx)
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return header, addr
+	}
+
+	return header, host
+}
+
+// ParseRateLimit parses a limit of the form method=rate or
+// method=rate:burst, e.g. /pkg.Service/Method=10:20. The burst defaults to
+// the rate rounded up
+func ParseRateLimit(spec string) (string, RateLimit, error) {
+	i := strings.LastIndex(spec, "=")
+	if i <= 0 {
+		return "", RateLimit{}, fmt.Errorf("lile: rate limit %q must be method=rate[:burst]", spec)
+	}
+
+	method, value := spec[:i], spec[i+1:]
+	rateStr, burstStr := value, ""
+	if j := strings.Index(value, ":"); j >= 0 {
+		rateStr, burstStr = value[:j], value[j+1:]
+	}
+
+	r, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || r < 0 {
+		return "", RateLimit{}, fmt.Errorf("lile: rate limit %q has an invalid rate", spec)
+	}
+
+	burst := int(r)
+	if float64(burst) < r {
+		burst++
+	}
+
+	if burstStr != "" {
+		burst, err = strconv.Atoi(burstStr)
+		if err != nil || burst < 0 {
+			return "", RateLimit{}, fmt.Errorf("lile: rate limit %q has an invalid burst", spec)
+		}
+	}
+
+	return method, RateLimit{Rate: r, Burst: burst}, nil
+}
+
+// rateLimitsValue is a flag that adds limits to a RateLimiter
+type rateLimitsValue struct {
+	l *RateLimiter
+}
+
+func (v rateLimitsValue) String() string {
+	specs := []string{}
+	for m, lim := range v.l.Limits {
+		specs = append(specs, fmt.Sprintf("%s=%g:%d", m, lim.Rate, lim.Burst))
+	}
+
+	sort.Strings(specs)
+	return "[" + strings.Join(specs, ",") + "]"
+}
+
+func (v rateLimitsValue) Set(spec string) error {
+	for _, s := range strings.Split(spec, ",") {
+		method, lim, err := ParseRateLimit(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+
+		v.l.Limits[method] = lim
+	}
+
+	return nil
+}
+
+func (v rateLimitsValue) Type() string {
+	return "limits"
+}
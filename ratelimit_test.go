@@ -0,0 +1,162 @@
+package lile
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter()
+	l.Limits["/pkg.Svc/Limited"] = RateLimit{Rate: 1, Burst: 2}
+
+	intercept := l.UnaryInterceptor()
+	call := func(ctx context.Context, method string) error {
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		return err
+	}
+
+	rejected := testutil.ToFloat64(rateLimitRejectedTotal.WithLabelValues("/pkg.Svc/Limited"))
+
+	a := peerContext("10.0.0.1")
+	assert.Nil(t, call(a, "/pkg.Svc/Limited"))
+	assert.Nil(t, call(a, "/pkg.Svc/Limited"))
+
+	err := call(a, "/pkg.Svc/Limited")
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		delay, err := ptypes.Duration(st.Details()[0].(*errdetails.RetryInfo).RetryDelay)
+		assert.Nil(t, err)
+		assert.True(t, delay > 0)
+	}
+
+	assert.Equal(t, rejected+1,
+		testutil.ToFloat64(rateLimitRejectedTotal.WithLabelValues("/pkg.Svc/Limited")))
+
+	// Other callers and methods have their own buckets
+	assert.Nil(t, call(peerContext("10.0.0.2"), "/pkg.Svc/Limited"))
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, call(a, "/pkg.Svc/Unlimited"))
+	}
+}
+
+func TestRateLimiterHeader(t *testing.T) {
+	l := NewRateLimiter()
+	l.Limits["*"] = RateLimit{Rate: 1, Burst: 1}
+	l.Header = "x-api-key"
+
+	key := func(k string) context.Context {
+		return metadata.NewIncomingContext(peerContext("10.0.0.1"),
+			metadata.Pairs("x-api-key", k))
+	}
+
+	assert.Nil(t, l.allow(key("a"), "/pkg.Svc/Get"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(l.allow(key("a"), "/pkg.Svc/Get")))
+	assert.Nil(t, l.allow(key("b"), "/pkg.Svc/Get"))
+}
+
+func TestParseRateLimit(t *testing.T) {
+	method, lim, err := ParseRateLimit("/pkg.Svc/Get=10:20")
+	assert.Nil(t, err)
+	assert.Equal(t, "/pkg.Svc/Get", method)
+	assert.Equal(t, RateLimit{Rate: 10, Burst: 20}, lim)
+
+	method, lim, err = ParseRateLimit("*=0.5")
+	assert.Nil(t, err)
+	assert.Equal(t, "*", method)
+	assert.Equal(t, RateLimit{Rate: 0.5, Burst: 1}, lim)
+
+	for _, spec := range []string{"10", "/pkg.Svc/Get=fast", "/pkg.Svc/Get=1:x"} {
+		_, _, err := ParseRateLimit(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestRateLimiterMaxHeaderBuckets(t *testing.T) {
+	l := NewRateLimiter()
+	l.Limits["*"] = RateLimit{Rate: 1, Burst: 1}
+	l.Header = "x-api-key"
+	l.MaxHeaderBuckets = 2
+
+	key := func(k string) context.Context {
+		return metadata.NewIncomingContext(peerContext("10.0.0.1"),
+			metadata.Pairs("x-api-key", k))
+	}
+
+	assert.Nil(t, l.allow(key("a"), "/pkg.Svc/Get"))
+	assert.Nil(t, l.allow(key("b"), "/pkg.Svc/Get"))
+
+	// New keys past the cap evict the least recently used
+	assert.Equal(t, codes.ResourceExhausted, status.Code(l.allow(key("a"), "/pkg.Svc/Get")))
+	assert.Nil(t, l.allow(key("c"), "/pkg.Svc/Get"))
+	assert.Len(t, l.buckets, 2)
+	assert.Contains(t, l.buckets, rateBucketKey{method: "/pkg.Svc/Get", caller: "x-api-key:a"})
+	assert.NotContains(t, l.buckets, rateBucketKey{method: "/pkg.Svc/Get", caller: "x-api-key:b"})
+
+	// An evicted key starts again with a full burst
+	assert.Nil(t, l.allow(key("b"), "/pkg.Svc/Get"))
+	assert.Len(t, l.buckets, 2)
+}
+
+func TestRateLimitFlags(t *testing.T) {
+	limiter := service.RateLimiter
+	defer func() { service.RateLimiter = limiter }()
+	service.RateLimiter = NewRateLimiter()
+	ints := len(service.UnaryInts)
+
+	cmd := BaseCommand("ratelimit", "")
+	err := cmd.ParseFlags([]string{
+		"--rate-limit", "/pkg.Svc/Get=5:10,*=100",
+		"--rate-limit-header", "x-api-key",
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, RateLimit{Rate: 5, Burst: 10}, service.RateLimiter.Limits["/pkg.Svc/Get"])
+	assert.Equal(t, RateLimit{Rate: 100, Burst: 100}, service.RateLimiter.Limits["*"])
+	assert.Equal(t, "x-api-key", service.RateLimiter.Header)
+	assert.Len(t, service.UnaryInts, ints)
+}
+
+func TestServiceRateLimits(t *testing.T) {
+	s := testService(t, "rate-limits")
+	s.RateLimiter.Limits["/grpc.health.v1.Health/Check"] = RateLimit{Rate: 0.001, Burst: 1}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
@@ -0,0 +1,97 @@
+package lile
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var panicsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lile_panics_total",
+	Help: "Panics recovered in RPC handlers.",
+}, []string{"method"})
+
+func init() {
+	prometheus.MustRegister(panicsTotal)
+}
+
+// RecoveryHandler maps a panic recovered from a handler to the error
+// returned to the caller, which should be a gRPC status
+type RecoveryHandler func(ctx context.Context, p interface{}) error
+
+// defaultRecoveryHandler returns codes.Internal without the panic value, so
+// internals don't leak to callers
+func defaultRecoveryHandler(ctx context.Context, p interface{}) error {
+	return status.Error(codes.Internal, "lile: internal error")
+}
+
+// RecoveryUnaryInterceptor turns panics in unary handlers into errors. The
+// panic is logged with its stack, counted and recorded on the active span,
+// then mapped to a status by the service's RecoveryHandler
+func (s *Service) RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = s.recovered(ctx, info.FullMethod, p, debug.Stack())
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor turns panics in stream handlers into errors
+// like RecoveryUnaryInterceptor
+func (s *Service) RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = s.recovered(ss.Context(), info.FullMethod, p, debug.Stack())
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func (s *Service) recovered(ctx context.Context, method string, p interface{}, stack []byte) error {
+	panicsTotal.WithLabelValues(method).Inc()
+
+	fields := []interface{}{
+		"method", method,
+		"panic", fmt.Sprint(p),
+		"stack", string(stack),
+	}
+
+	if addr := peerAddr(ctx); addr != "" {
+		fields = append(fields, "peer", addr)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if sc := span.SpanContext(); sc.HasTraceID() {
+		fields = append(fields, "trace_id", sc.TraceID().String())
+	}
+
+	s.log().Error("lile: panic in rpc handler", fields...)
+
+	span.RecordError(fmt.Errorf("panic: %v", p),
+		trace.WithAttributes(semconv.ExceptionStacktraceKey.String(string(stack))))
+	span.SetStatus(otelcodes.Error, "panic")
+
+	h := s.RecoveryHandler
+	if h == nil {
+		h = defaultRecoveryHandler
+	}
+
+	return h(ctx, p)
+}
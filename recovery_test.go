@@ -0,0 +1,110 @@
+package lile
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lileio/lile/v2/test"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecovery(t *testing.T) {
+	logger := newRecordingLogger()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	s := NewService("recovery")
+	s.Logger = logger
+
+	method := "/pkg.Orders/Panics"
+	panics := testutil.ToFloat64(panicsTotal.WithLabelValues(method))
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), method)
+	_, err := s.RecoveryUnaryInterceptor()(ctx, nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("boom")
+		})
+	span.End()
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, err.Error(), "boom")
+	assert.Equal(t, panics+1, testutil.ToFloat64(panicsTotal.WithLabelValues(method)))
+
+	e := <-logger.entries
+	assert.Equal(t, "error", e.level)
+	assert.Equal(t, method, e.fields["method"])
+	assert.Equal(t, "boom", e.fields["panic"])
+	assert.True(t, strings.Contains(e.fields["stack"].(string), "recovery_test.go"))
+	assert.Equal(t, span.SpanContext().TraceID().String(), e.fields["trace_id"])
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, otelcodes.Error, spans[0].Status.Code)
+	assert.Equal(t, "exception", spans[0].Events[0].Name)
+}
+
+func TestRecoveryHandler(t *testing.T) {
+	s := NewService("recovery-handler")
+	s.Logger = newRecordingLogger()
+	s.RecoveryHandler = func(ctx context.Context, p interface{}) error {
+		return status.Errorf(codes.Unavailable, "recovered from %v", p)
+	}
+
+	err := s.RecoveryStreamInterceptor()(nil, &contextServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/pkg.Orders/Watch"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			panic("stream boom")
+		})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "recovered from stream boom", status.Convert(err).Message())
+}
+
+type panickingAuthenticator struct{}
+
+func (panickingAuthenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	panic("auth boom")
+}
+
+func TestRecoveryCoversInterceptors(t *testing.T) {
+	s := testService(t, "recovery-chain")
+	s.Logger = newRecordingLogger()
+	s.Auth = AuthConfig{Authenticator: panickingAuthenticator{}}
+	s.GRPCImplementation = func(g *grpc.Server) {
+		g.RegisterService(&echoDesc, struct{}{})
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	method := "/lile.test.Echo/Chat"
+	panics := testutil.ToFloat64(panicsTotal.WithLabelValues(method))
+
+	// The authenticator runs before any handler, the panic is still
+	// recovered
+	stream, err := conn.NewStream(ctx, &echoDesc.Streams[0], method)
+	assert.Nil(t, err)
+	assert.Equal(t, codes.Internal, status.Code(stream.RecvMsg(&test.Account{})))
+	assert.Equal(t, panics+1, testutil.ToFloat64(panicsTotal.WithLabelValues(method)))
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
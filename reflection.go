@@ -0,0 +1,45 @@
+package lile
+
+import (
+	"bytes"
+	"compress/gzip"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// registerDescriptors adds the files in a serialized FileDescriptorSet
+// (as produced by protoc --descriptor_set_out --include_imports) to the
+// protobuf registry so the reflection service can serve them. Files that
+// the generated code already registered are left untouched
+func registerDescriptors(b []byte) error {
+	set := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, set); err != nil {
+		return err
+	}
+
+	for _, fd := range set.File {
+		if proto.FileDescriptor(fd.GetName()) != nil {
+			continue
+		}
+
+		raw, err := proto.Marshal(fd)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(raw); err != nil {
+			return err
+		}
+
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		proto.RegisterFile(fd.GetName(), buf.Bytes())
+	}
+
+	return nil
+}
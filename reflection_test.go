@@ -0,0 +1,98 @@
+package lile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterDescriptors(t *testing.T) {
+	set := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("lile/reflection_test.proto"),
+				Package: proto.String("lile.test"),
+				Service: []*descriptor.ServiceDescriptorProto{
+					{Name: proto.String("Reflected")},
+				},
+			},
+		},
+	}
+
+	b, err := proto.Marshal(set)
+	assert.Nil(t, err)
+
+	assert.Nil(t, registerDescriptors(b))
+	assert.NotNil(t, proto.FileDescriptor("lile/reflection_test.proto"))
+
+	// Registering again is a no-op rather than a duplicate registration
+	assert.Nil(t, registerDescriptors(b))
+}
+
+// listServices lists the services of a running service through the
+// reflection API
+func listServices(t *testing.T, s *Service) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(), grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, svc := range res.GetListServicesResponse().GetService() {
+		names = append(names, svc.GetName())
+	}
+
+	return names, nil
+}
+
+func TestReflection(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		s := testService(t, "reflection")
+		s.EnableReflection = enabled
+
+		errs := make(chan error, 1)
+		go func() { errs <- s.Run() }()
+		waitForState(t, s, StateServing)
+
+		names, err := listServices(t, s)
+		if enabled {
+			assert.Nil(t, err)
+			assert.Contains(t, names, "grpc.reflection.v1alpha.ServerReflection")
+			assert.Contains(t, names, "grpc.health.v1.Health")
+		} else {
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		}
+
+		s.Shutdown()
+		assert.Nil(t, <-errs)
+	}
+}
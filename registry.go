@@ -2,7 +2,8 @@ package lile
 
 // Registry is the interface to implement for external registry providers
 type Registry interface {
-	// Register a service
+	// Register a service, it is called once the gRPC listener is bound so
+	// s.Address() returns the address to advertise
 	Register(s *Service) error
 	// Deregister a service
 	DeRegister(s *Service) error
@@ -2,100 +2,396 @@ package lile
 
 import (
 	"context"
-	"net"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
+// handlingTimeHistogram guards the global gRPC metrics, which aren't safe to
+// configure from services starting concurrently
+var handlingTimeHistogram sync.Once
+
+// Run is a blocking cmd to run the gRPC and metrics server for the global
+// service
+func Run() error {
+	return service.Run()
+}
+
+// ServeGRPC creates and runs a blocking gRPC server for the global service
+func ServeGRPC() error {
+	return service.ServeGRPC()
+}
+
+// Shutdown gracefully shuts down the gRPC and metrics servers of the global
+// service
+func Shutdown() {
+	service.Shutdown()
+}
+
 // Run is a blocking cmd to run the gRPC and metrics server.
 // You should listen to os signals and call Shutdown() if you
-// want a graceful shutdown or want to handle other goroutines
-func Run() error {
-	if service.Registry != nil {
-		service.Registry.Register(service)
+// want a graceful shutdown. Background workers added with Go run alongside
+// the server, and Run returns the first error from either once the
+// shutdown has finished
+func (s *Service) Run() error {
+	s.mu.Lock()
+	if s.state != StateCreated {
+		s.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	s.state = StateStarting
+	s.mu.Unlock()
+
+	if err := runHooks(context.Background(), s.OnStart); err != nil {
+		s.mu.Lock()
+		// Shutdown may have started while the hooks ran, it stops the
+		// service itself
+		if s.state == StateStarting {
+			s.setState(StateStopped)
+		}
+		s.mu.Unlock()
+		return err
+	}
+
+	s.mu.Lock()
+	// Shutdown was called while the start hooks ran
+	if s.state != StateStarting {
+		s.mu.Unlock()
+		return nil
+	}
+
+	// The gRPC listener is bound before registering, so the registry is
+	// given the real address when listening on port 0
+	if err := s.listen(); err != nil {
+		s.setState(StateStopped)
+		s.mu.Unlock()
+		return err
+	}
+
+	// Start a metrics server, unless metrics are served on the gRPC port.
+	// It's bound before serving gRPC so a port clash stops Run
+	if !s.SinglePort {
+		if err := s.startPrometheusServer(); err != nil {
+			s.ServiceListener.Close()
+			s.setState(StateStopped)
+			s.mu.Unlock()
+			return err
+		}
+	}
+
+	// The group is created before unlocking, so a Shutdown from here on
+	// can always cancel the workers
+	s.startWorkers()
+	s.mu.Unlock()
+
+	if s.Registry != nil {
+		s.Registry.Register(s)
 	}
 
-	// Start a metrics server in the background
-	startPrometheusServer()
+	// Create and then serve a gRPC server next to the workers, ServeGRPC
+	// returns straight away if Shutdown was called in the meantime
+	s.group.Go(s.ServeGRPC)
 
-	// Create and then server a gRPC server
-	err := ServeGRPC()
-	if service.Registry != nil {
-		service.Registry.DeRegister(service)
+	err := s.group.Wait()
+	if s.Registry != nil {
+		s.Registry.DeRegister(s)
 	}
+
+	// The group only returns once shutdown has begun, either from
+	// Shutdown or from a failure. Waiting for it to finish means stop hooks
+	// have run by the time the process exits
+	<-s.Done()
 	return err
 }
 
 // ServeGRPC creates and runs a blocking gRPC server
-func ServeGRPC() error {
-	var err error
-	service.ServiceListener, err = net.Listen("tcp", service.Config.Address())
+func (s *Service) ServeGRPC() error {
+	s.mu.Lock()
+	switch s.state {
+	case StateCreated:
+		s.state = StateStarting
+	case StateStarting:
+	case StateDraining, StateStopped:
+		// Shutdown was called after Run bound the listener
+		if s.ServiceListener != nil {
+			s.ServiceListener.Close()
+		}
+		s.mu.Unlock()
+		return nil
+	default:
+		s.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+
+	// Servers are created while holding the lock so Shutdown either sees
+	// all of them or none of them
+	gs, err := s.setupGRPC()
+	if err == nil {
+		s.state = StateServing
+	}
+	s.mu.Unlock()
+
 	if err != nil {
 		return err
 	}
 
-	logrus.Infof("Serving gRPC on %s", service.Config.Address())
-	return createGrpcServer().Serve(service.ServiceListener)
+	s.startHealthChecks()
+
+	if s.SinglePort {
+		s.log().Info("lile: serving gRPC, metrics and HTTP", "addr", s.ServiceListener.Addr().String())
+		err = s.serveMultiplexed(gs)
+	} else {
+		s.log().Info("lile: serving gRPC", "addr", s.ServiceListener.Addr().String())
+		err = gs.Serve(s.ServiceListener)
+	}
+
+	// Shutdown stopped the server before it started serving
+	if err == grpc.ErrServerStopped {
+		s.ServiceListener.Close()
+		return nil
+	}
+
+	return err
 }
 
-// Shutdown gracefully shuts down the gRPC and metrics servers
-func Shutdown() {
-	logrus.Infof("lile: Gracefully shutting down gRPC and Prometheus")
+// setupGRPC creates the listener, gRPC server and gateway, it must be
+// called with mu held
+func (s *Service) setupGRPC() (*grpc.Server, error) {
+	if err := s.listen(); err != nil {
+		return nil, err
+	}
 
-	if service.Registry != nil {
-		service.Registry.DeRegister(service)
+	gs, err := s.createGrpcServer()
+	if err != nil {
+		return nil, err
 	}
 
-	service.GRPCServer.GracefulStop()
+	if err := s.startGateway(gs); err != nil {
+		return nil, err
+	}
 
-	// 30 seconds is the default grace period in Kubernetes
-	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
-	defer cancel()
-	if err := service.PrometheusServer.Shutdown(ctx); err != nil {
-		logrus.Infof("Timeout during shutdown of metrics server. Error: %v", err)
+	return gs, nil
+}
+
+// listen creates the gRPC listener unless one was supplied, it must be
+// called with mu held
+func (s *Service) listen() error {
+	if s.ServiceListener != nil {
+		return nil
+	}
+
+	l, err := s.Config.Listen("grpc")
+	if err != nil {
+		return err
 	}
+
+	s.ServiceListener = l
+	return nil
 }
 
-func createGrpcServer() *grpc.Server {
-	service.GRPCOptions = append(service.GRPCOptions, grpc.UnaryInterceptor(
-		grpc_middleware.ChainUnaryServer(service.UnaryInts...)))
+// Shutdown gracefully shuts down the gRPC and metrics servers. The service
+// is marked NOT_SERVING, then after PreStopDelay in-flight requests are
+// drained for up to ShutdownTimeout before they are stopped forcefully.
+// It is safe to call more than once, later calls wait for the first one
+func (s *Service) Shutdown() {
+	s.mu.Lock()
+	switch s.state {
+	case StateCreated:
+		s.setState(StateStopped)
+		s.mu.Unlock()
+		return
+	case StateDraining, StateStopped:
+		done := s.doneChan()
+		s.mu.Unlock()
+		<-done
+		return
+	}
+	s.state = StateDraining
+	s.mu.Unlock()
+
+	s.log().Info("lile: gracefully shutting down gRPC and Prometheus")
+
+	if s.Registry != nil {
+		s.Registry.DeRegister(s)
+	}
+
+	s.shutdownPhase("not_serving", s.shutdownHealth)
+	s.shutdownPhase("pre_stop_delay", func() {
+		time.Sleep(s.PreStopDelay)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+
+	s.shutdownPhase("drain", func() {
+		// The gateway forwards to gRPC, so it's drained first
+		s.stopHTTPServer(ctx, "gateway", s.GatewayServer)
+		s.stopGRPC(ctx)
+		if s.gatewayConnection != nil {
+			s.gatewayConnection.Close()
+		}
+
+		s.stopHTTPServer(ctx, "HTTP", s.HTTPServer)
+		s.stopHTTP2(ctx)
+		s.waitForWorkers(ctx)
+	})
+
+	s.shutdownPhase("stop_hooks", func() {
+		if err := runHooks(ctx, s.OnStop); err != nil {
+			s.log().Error("lile: stop hook failed", "error", err)
+		}
+	})
+
+	// Metrics are served until last so the drain can be observed
+	s.shutdownPhase("metrics", func() {
+		s.stopHTTPServer(ctx, "metrics", s.PrometheusServer)
+	})
+
+	s.mu.Lock()
+	s.setState(StateStopped)
+	s.mu.Unlock()
+}
+
+func (s *Service) createGrpcServer() (*grpc.Server, error) {
+	// Options are copied so creating a server again doesn't chain the
+	// interceptors twice
+	opts := append([]grpc.ServerOption{}, s.GRPCOptions...)
+
+	// In single port mode TLS is terminated before connections are routed
+	if s.Config.TLSEnabled() && !s.SinglePort {
+		cfg, err := s.Config.serverTLSConfig(s.log())
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.Creds(gatewayCredentials{credentials.NewTLS(cfg)}))
+	}
+
+	// Telemetry runs first so its spans cover the other interceptors, and
+	// the access log can record the trace ID
+	// Recovery sits right inside telemetry, so panics anywhere in the
+	// chain are recovered and recorded on the span
+	unary := []grpc.UnaryServerInterceptor{
+		s.Telemetry.UnaryServerInterceptor(),
+		s.RecoveryUnaryInterceptor(),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		s.Telemetry.StreamServerInterceptor(),
+		s.RecoveryStreamInterceptor(),
+	}
+
+	if !s.AccessLog.Disabled {
+		unary = append(unary, s.AccessLogUnaryInterceptor())
+		stream = append(stream, s.AccessLogStreamInterceptor())
+	}
 
-	service.GRPCOptions = append(service.GRPCOptions, grpc.StreamInterceptor(
-		grpc_middleware.ChainStreamServer(service.StreamInts...)))
+	// Callers over their rate limit are turned away before taking a
+	// concurrency slot
+	if s.RateLimiter != nil && len(s.RateLimiter.Limits) > 0 {
+		unary = append(unary, s.RateLimiter.UnaryInterceptor())
+		stream = append(stream, s.RateLimiter.StreamInterceptor())
+	}
+
+	// Excess requests are shed before any other work is done on them
+	if s.Concurrency.MaxLimit > 0 {
+		cl := newConcurrencyLimiter(s.Name, s.Concurrency)
+		unary = append(unary, cl.UnaryInterceptor())
+		stream = append(stream, cl.StreamInterceptor())
+	}
+
+	// Deadlines run inside the limiter, which backs off when calls exceed
+	// them
+	if s.Deadlines.enabled() {
+		unary = append(unary, DeadlineUnaryInterceptor(s.Deadlines))
+		stream = append(stream, DeadlineStreamInterceptor(s.Deadlines))
+	}
+
+	if s.Auth.Authenticator != nil {
+		unary = append(unary, AuthUnaryInterceptor(s.Auth))
+		stream = append(stream, AuthStreamInterceptor(s.Auth))
+	}
+
+	if len(s.Auth.Policy) > 0 {
+		unary = append(unary, AuthorizationUnaryInterceptor(s.Auth.Policy))
+		stream = append(stream, AuthorizationStreamInterceptor(s.Auth.Policy))
+	}
+
+	// Results are only replayed to callers allowed to make the call
+	if s.Idempotency.Store != nil && len(s.Idempotency.Methods) > 0 {
+		unary = append(unary, newIdempotency(s.Idempotency, s.log()).UnaryInterceptor())
+	}
+
+	unary = append(unary, s.UnaryInts...)
+	stream = append(stream, s.StreamInts...)
+
+	opts = append(opts, grpc.UnaryInterceptor(
+		grpc_middleware.ChainUnaryServer(unary...)))
 
-	service.GRPCServer = grpc.NewServer(
-		service.GRPCOptions...,
-	)
+	opts = append(opts, grpc.StreamInterceptor(
+		grpc_middleware.ChainStreamServer(stream...)))
 
-	service.GRPCImplementation(service.GRPCServer)
+	s.GRPCServer = grpc.NewServer(opts...)
 
-	grpc_prometheus.EnableHandlingTimeHistogram(
-		func(opt *prometheus.HistogramOpts) {
-			opt.Buckets = prometheus.ExponentialBuckets(0.005, 1.4, 20)
-		},
-	)
+	s.GRPCImplementation(s.GRPCServer)
 
-	grpc_prometheus.Register(service.GRPCServer)
-	return service.GRPCServer
+	s.HealthServer = newHealthServer()
+	healthpb.RegisterHealthServer(s.GRPCServer, s.HealthServer)
+
+	if s.EnableReflection {
+		if len(s.ReflectionDescriptors) > 0 {
+			if err := registerDescriptors(s.ReflectionDescriptors); err != nil {
+				return nil, err
+			}
+		}
+
+		reflection.Register(s.GRPCServer)
+	}
+
+	handlingTimeHistogram.Do(func() {
+		grpc_prometheus.EnableHandlingTimeHistogram(
+			func(opt *prometheus.HistogramOpts) {
+				opt.Buckets = prometheus.ExponentialBuckets(0.005, 1.4, 20)
+			},
+		)
+	})
+
+	grpc_prometheus.Register(s.GRPCServer)
+	return s.GRPCServer, nil
 }
 
-func startPrometheusServer() {
-	service.PrometheusServer = &http.Server{Addr: service.PrometheusConfig.Address()}
+// startPrometheusServer binds the metrics and admin listener and serves it
+// in the background. A bind error is returned unless MetricsOptional is set
+func (s *Service) startPrometheusServer() error {
+	l, err := s.PrometheusConfig.Listen("metrics")
+	if err != nil {
+		if s.MetricsOptional {
+			s.log().Warn("lile: metrics disabled, cannot listen", "addr", s.PrometheusConfig.Address(), "error", err)
+			return nil
+		}
+
+		return fmt.Errorf("lile: metrics listener on %s: %v", s.PrometheusConfig.Address(), err)
+	}
 
-	http.Handle("/metrics", promhttp.Handler())
-	logrus.Infof("Prometheus metrics at http://%s/metrics", service.PrometheusConfig.Address())
+	s.PrometheusServer = &http.Server{
+		Addr:    l.Addr().String(),
+		Handler: s.httpHandler(),
+	}
+	s.log().Info("lile: serving Prometheus metrics and admin endpoints", "addr", l.Addr().String())
 
-	go func() {
-		if err := service.PrometheusServer.ListenAndServe(); err != nil {
-			// cannot panic, because this probably is an intentional close
-			logrus.Errorf("Prometheus http server: ListenAndServe() error: %s", err)
+	go func(srv *http.Server) {
+		if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+			s.log().Error("lile: Prometheus http server failed", "error", err)
 		}
-	}()
+	}(s.PrometheusServer)
+
+	return nil
 }
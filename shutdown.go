@@ -0,0 +1,89 @@
+package lile
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	shutdownPhaseSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lile_shutdown_phase_duration_seconds",
+		Help: "Duration of each phase of the last shutdown.",
+	}, []string{"service", "phase"})
+
+	shutdownForcedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lile_shutdown_forced_total",
+		Help: "Shutdowns that stopped in-flight RPCs after the shutdown timeout.",
+	}, []string{"service"})
+)
+
+func init() {
+	prometheus.MustRegister(shutdownPhaseSeconds, shutdownForcedTotal)
+}
+
+// shutdownPhase runs and times a phase of the shutdown
+func (s *Service) shutdownPhase(phase string, fn func()) {
+	start := time.Now()
+	s.log().Info("lile: shutdown phase started", "phase", phase)
+
+	fn()
+
+	d := time.Since(start)
+	shutdownPhaseSeconds.WithLabelValues(s.Name, phase).Set(d.Seconds())
+	s.log().Info("lile: shutdown phase finished", "phase", phase, "duration", d)
+}
+
+// stopGRPC gracefully stops the gRPC server, falling back to stopping it
+// forcefully, which cancels in-flight RPCs, when the context is done
+func (s *Service) stopGRPC(ctx context.Context) {
+	if s.GRPCServer == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.GRPCServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.log().Warn("lile: shutdown timeout exceeded, stopping in-flight RPCs", "timeout", s.ShutdownTimeout)
+		shutdownForcedTotal.WithLabelValues(s.Name).Inc()
+		s.GRPCServer.Stop()
+		<-done
+	}
+}
+
+// stopHTTPServer gracefully shuts down a HTTP server, closing any remaining
+// connections when the context is done
+func (s *Service) stopHTTPServer(ctx context.Context, name string, srv *http.Server) {
+	if srv == nil {
+		return
+	}
+
+	if err := srv.Shutdown(ctx); err != nil {
+		s.log().Warn("lile: timeout during server shutdown", "server", name, "error", err)
+		srv.Close()
+	}
+}
+
+// stopHTTP2 drains the HTTP/2 connections served next to gRPC in single
+// port mode, which were sent a GOAWAY when the HTTP server shut down
+func (s *Service) stopHTTP2(ctx context.Context) {
+	s.mu.Lock()
+	h2 := s.http2Conns
+	s.mu.Unlock()
+
+	if h2 == nil {
+		return
+	}
+
+	if err := h2.shutdown(ctx); err != nil {
+		s.log().Warn("lile: timeout during server shutdown", "server", "HTTP/2", "error", err)
+	}
+}
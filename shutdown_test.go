@@ -0,0 +1,41 @@
+package lile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestShutdownForcesStopAfterTimeout(t *testing.T) {
+	s := testService(t, "forced")
+	s.ShutdownTimeout = 100 * time.Millisecond
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	conn, err := grpc.Dial(s.Config.Address(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	// A watch stream stays open until the client or server ends it
+	stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(),
+		&healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Nil(t, err)
+
+	forced := testutil.ToFloat64(shutdownForcedTotal.WithLabelValues("forced"))
+	start := time.Now()
+	s.Shutdown()
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.Nil(t, <-errs)
+
+	assert.Equal(t, forced+1, testutil.ToFloat64(shutdownForcedTotal.WithLabelValues("forced")))
+	assert.True(t, testutil.ToFloat64(shutdownPhaseSeconds.WithLabelValues("forced", "drain")) > 0)
+}
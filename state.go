@@ -0,0 +1,79 @@
+package lile
+
+import "errors"
+
+// ErrAlreadyStarted is returned by Run and ServeGRPC when the service has
+// already been started, a service can only be run once
+var ErrAlreadyStarted = errors.New("lile: service has already been started")
+
+// State is the lifecycle state of a Service
+type State int
+
+const (
+	// StateCreated is a service that hasn't been run yet
+	StateCreated State = iota
+	// StateStarting is a service that is creating its servers
+	StateStarting
+	// StateServing is a service that is accepting requests
+	StateServing
+	// StateDraining is a service that is shutting down
+	StateDraining
+	// StateStopped is a service that has shut down
+	StateStopped
+)
+
+func (st State) String() string {
+	switch st {
+	case StateCreated:
+		return "created"
+	case StateStarting:
+		return "starting"
+	case StateServing:
+		return "serving"
+	case StateDraining:
+		return "draining"
+	case StateStopped:
+		return "stopped"
+	}
+
+	return "unknown"
+}
+
+// State returns the current lifecycle state of the service
+func (s *Service) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Done returns a channel that is closed once the service has shut down
+func (s *Service) Done() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.doneChan()
+}
+
+// doneChan must be called with mu held
+func (s *Service) doneChan() chan struct{} {
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+
+	return s.done
+}
+
+// setState must be called with mu held
+func (s *Service) setState(st State) {
+	s.state = st
+	if st != StateStopped {
+		return
+	}
+
+	done := s.doneChan()
+	select {
+	case <-done:
+		// Already stopped
+	default:
+		close(done)
+	}
+}
@@ -0,0 +1,131 @@
+package lile
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForState(t *testing.T, s *Service, st State) {
+	deadline := time.Now().Add(5 * time.Second)
+	for s.State() != st {
+		if time.Now().After(deadline) {
+			t.Fatalf("service didn't reach state %s, it is %s", st, s.State())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestShutdownBeforeRun(t *testing.T) {
+	s := testService(t, "early")
+	s.Shutdown()
+
+	assert.Equal(t, StateStopped, s.State())
+	<-s.Done()
+
+	assert.Equal(t, ErrAlreadyStarted, s.Run())
+}
+
+func TestRunAndShutdownAreIdempotent(t *testing.T) {
+	s := testService(t, "idempotent")
+	assert.Equal(t, StateCreated, s.State())
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	healthStatus(t, s, "")
+
+	assert.Equal(t, StateServing, s.State())
+	assert.Equal(t, ErrAlreadyStarted, s.Run())
+	assert.Equal(t, ErrAlreadyStarted, s.ServeGRPC())
+	assert.Len(t, s.GRPCOptions, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Shutdown()
+		}()
+	}
+	wg.Wait()
+
+	assert.Nil(t, <-errs)
+	assert.Equal(t, StateStopped, s.State())
+	<-s.Done()
+}
+
+func TestShutdownWhileStarting(t *testing.T) {
+	s := testService(t, "racing")
+	s.OnStart = append(s.OnStart, func(ctx context.Context) error {
+		go s.Shutdown()
+		for s.State() == StateStarting {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		return nil
+	})
+
+	assert.Nil(t, s.Run())
+	<-s.Done()
+	assert.Nil(t, s.GRPCServer)
+}
+
+func TestStartHookFailsAfterShutdown(t *testing.T) {
+	s := testService(t, "hook-fails")
+	s.OnStart = append(s.OnStart, func(ctx context.Context) error {
+		go s.Shutdown()
+		<-s.Done()
+		return errors.New("hook failed")
+	})
+
+	assert.EqualError(t, s.Run(), "hook failed")
+	assert.Equal(t, StateStopped, s.State())
+}
+
+// shutdownRegistry shuts the service down while it's being registered
+type shutdownRegistry struct{}
+
+func (shutdownRegistry) Register(s *Service) error {
+	done := make(chan struct{})
+	go func() {
+		s.Shutdown()
+		close(done)
+	}()
+
+	<-done
+	return nil
+}
+
+func (shutdownRegistry) DeRegister(s *Service) error { return nil }
+
+func (shutdownRegistry) Get(name string) (string, error) { return "", nil }
+
+func TestShutdownWhileRegistering(t *testing.T) {
+	s := testService(t, "registering")
+	s.Registry = shutdownRegistry{}
+
+	stopped := make(chan struct{})
+	s.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+		return nil
+	})
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	select {
+	case err := <-errs:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run didn't return after Shutdown")
+	}
+
+	<-stopped
+	assert.Equal(t, StateStopped, s.State())
+}
@@ -8,6 +8,6 @@ import (
 )
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00Dockerfile.tmplUT\x05\x00\x01\xe0a\xed\\t\x90\xc1O\xc20\x14\xc6\xef\xfd+^v\xe0dW\x8e\xc6d\x07\x04$DY\xc9\xc0\x18\x82\xc4\x94\xb6\x94\xc5\xadm\xdaNM\x96\xfd\xeffs\x01\x85x\xfb\xde\xfb\xfa\xbd\xfe\xde{\xc8\xe8\x02\x94)\x98Vw\xac\xb0\xb9\x960Z\xc1\xbe\xca\x0b\x81\xa5\xfe@/4{\x9c\xcc3 u\x0dq\xcaJ	M\x83\xb2\xe7\x14\x98}\x87\xca\n\x16$\x0c\x06}\xa5\x1c\x13]\xf9\x8a\x00\xa0k2!\x00cm0g\xfc(a\xcf\xfc\x11T\x1e\xc0X\xa9\xbd?\xa21]n@\x99\xb84\xe2\xcf\x1f\xe4\xa7w\xf2}U^\xf9\xbe*;\x14e\xa0\x8d\x0b\xf3\xa9\x0b\xc3\xfaL|\x8d<\x9e\xd1\xb7i:\xba\x7f\x9aN\x92!\xcc(]%E\xae\xab\xafv@\xb71\xe0^\x90\xba\xee\x92M\x03\xf1Y#\x84\xbasy\xeeX\xe0=;\xc6\x07g\xca\xe4t1 2p\xe2}A\xb8t\xc1\x13\xcep+\xf2C\xceY\x90>\xe6.\\>\xf9o\xd0\xefu/\xb1\x08\x9a\xa6\xebl\xb3\xa4\xf3t\x0d\xdb\xe8\xecD;4^L`\x1bU6\xba\x81\x08c\xe5,\xc7\xd6\xb8\x90\xdc\x0e\xa3\x1d\xfa\x0e\x00\x00\xff\xffPK\x07\x08f\x96,\xd5#\x01\x00\x00\xf1\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00Makefile.tmplUT\x05\x00\x01\xe0a\xed\\,\x8e1k\xc30\x10F\xe7\xdc\xaf8\xe8\xda;\xd3\xd5\xe0\xbd]\xd2\xae\x9d\x82b.\x17Q\xd9'\xa4\x93\x97\x90\xff^,g{\x0f>>\xde\x1bnq\xc4\x9bOK\xf8\x13\xe0\x9f\xcf\xef\xf3\xef\x88\xb9\x98\x1b\xbaT\x07\xe8<\xc2I\x0dU\x1c5\xfa\xbd]y\xb6ePKa\xd5\xa1\x0f\xae\xedv\xc0L*+\xa9\xc1\xe9P\xa4/d|<\xf8\x1c\x16y>\xf9\xb8&J1	U)\x9b\x94\x8b5\x9f\x18\x89\xd4:\xe6\xd44\xaeu\xd2\x92\xe7\xf7\x1c\xfc^\xa7j\xad\xccr)\x92\x82\xc7MF\x06\xd8\xeb^\xa5=nw\xa4\x8c\x1fH\x1b\xf2\xc0\xcc\xf0\x1f\x00\x00\xff\xffPK\x07\x08\x1e\x06\xed\xa9\xa8\x00\x00\x00\xdd\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x19\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00client.tmplUT\x05\x00\x01\x83\xd5\x8b]|SAk\xe3<\x10=K\xbfb>\x1f\xbe\xb5!\x95a\x8f\x0b9\xa5l)t\xb3\xd0n\xe8\xb1\xa8\xf2\xc4\x16\x95g\xccXI\x1aB\xfe\xfb\"\xdb\xdbu\xbb4\xbaX\x9e\x99\xf7\xf4\xfc\xf4\xdcY\xf7bk\x84\xd3	\xcc\xda\xb6\x08\xe7\xb3\xd6\xbe\xedX\"\xe4Ze\xfd\x91\\\xa6\xb5\xcaj\x1f\x9b\xdd\xb3q\xdc\x96\xb5t\xee\n\x1d\xf7\xc7>\xe2\xf4\xca\x1dR\x14\xeb<\xd5e\xcd%\xc7T\xcd\xde\xe3\x82\x0f\xe8yx\x94\xfb\xaf\x99V3\x10\xcc\x07\xe7d\xb3\xfdU\xcd\x99\x86i%\xfe\xa7\xd6WU\xc0\x83\x15\x84K\x02\xf9j\xa8\xfc\x9d\x1e\x841\xd7\x01M\xcd\xc1RmX\xear\x94\\h\xbd\xb7\x92>\xde\xb5\xb0\x84\xff\x93\x03\xe6\xc7.\xe2\xeb\xe9\xac\xd5*x\xa48\xd8\xb5\xb2-\x86\x95\xedq\xf2ml%\xfcvG\x0en0~>\x95\x17\x17(\xe0\x94\xce6w\xec^\xf2B\xab\n\xb7(\xe0Z\xb3\xa10\x96\xb4\xf2[\x98f\xff[\x02\xf9\x90 J0\xee\x84\xa6\x86Vg\xadU\x8f\xb2\xf7\x0e7\xf7w\xf0m	\xc9y\xb3\xb9\xbf\xfb\xce\xf206\xf2,\xc9\xb8^?L\x02\xb2D^\x96\xf0\x88P1}\x89@\x88\x15D\x06\x14a\x81\x06\x05\x17`{\x88\x8d\xef\xc1	\xda\x88=X\xe8\x98\x03X\xaa\xc01\x11\xba\xe8\x99\xfa\x81\xe7\xe0C\x80\xc6v\x1d\x12\x04\x1bQ\xb4J#\x0bxJz\x92\xe1\xe6\xda\xdb\x90k5\x93\xba\xd0J\x0d\xadG\x1f\x9b[\xea\xd1\xed\x04\xf3\xe2]yCV\x8e\xb7\x14Q\x1cv\x91%\x7f\x0b\xc6\xc7\xf5!(f\xd5XO\x03|\xba\x8aO\x91i\x0d\x96\xad\x98\"\xbe\xc6q~~h\xb1\xb8\x08\x1e\xff\x02\xf3\xb3C\xfa5F\xf8_\x8aY\xbe\xcdM\xe0g\x1b\xd2(J^\\ \x1f\xbc(\xd2]\xb9\xe0\x93\x93k<\\\x08[\xb2\xbcx\xcb\xee\x12\\\xf0\xfaOZ\xd2\xfe\xac\x7f\x07\x00\x00\xff\xffPK\x07\x08\xe4\xe99\xbd\xdf\x01\x00\x00\x07\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x1d\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00cmd_main.tmplUT\x05\x00\x01\x8a\xd5\x8b]t\x91\xc1\x8e\xdb \x10\x86\xcf\xccS\x8c|XA\x15\x81\xd4c\xa4\x9e\xa2m/\xddj\xd5<@\x85\xf1\x84E\x05cap+E~\xf7\n\xe2l\xf7\xe0\x9cl\xfe\x8f\xf9\xff\x19f\xd2\xe6\xb7\xb6\x84A\xbb\x11\xc0\x85)\xa6\x8c\x1c\xd8/\xecF\xca\xea-\xe7IMS\x8a\x97\x0e\x80u\xd6\xe5\xb7\xd2K\x13\x83\xf2\xce\x93\x8b\xed\xa3\x96\xcf\xdd>\x8c6\xed\x93K\x8a\x81\xc6e\x1fN\xa5\x9fK\xff\xd0\xf5\x1d\xab\xe0\x86\xc1\xd3\x1f\x9dH\x0dt\xd1\xc5\xe7\xb9\x95\xc4h=I\x1b\xbd\x1e\xad\x8c\xc9*\x9b&S\xc9\xf5\x8a\xf2%\x0e\xc5\xd3\x0f\x1d\x08\xd7uWT3\xa5\x85\xd2>\xab\xca\xfd\xdf\x84\xa1\x03\x01p)\xa3i/\xc8\x05^\x81\xd5\xb1\xe5\x99\xf2wZ\xc8\x7fM1<\x8f\x0b\x17\xc0f<~\xc1\xa7\x9b\xb9\xac>'\x1d\xc8\x9f\xf4|7?7t]\x01X\x9d\xb5\xe5\xf0\xeeCb'6r\xbb\xc9k0\xb7\xf8\xa9\xce\xb7i\xad\x03\xf6\xa1F\xfe$\xeb\xe6L\xe9q\"\xb7\x07\x9c\x05\xb0U\x00\xb0\xdb\xf3\xd6\xfeO\xde\xd1\x98\xf9\xd3\xa6\xdc\x8e\xd5\xbdV9\xd3<\x8e\xd8\xfa\xf9\xe6c\xaf\xfd\xa6s\xd1\xb2\x0f\xc0\xd8k\x8a\x8b\x1b(\x1d\x11\x11\xb7\xa5\xcb\xd7\xd2\x9fK\x7fG\\\xd4\x8b/\xef\xab<\"\xde\x97)\xff\xab\x87\xad;\x13\x06\xf9\xfc\x97L\xc9\xc4\x05\xac\xf0/\x00\x00\xff\xffPK\x07\x08\xf2\xc9d\xa2[\x01\x00\x00\xc0\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\"\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00cmd_root.tmplUT\x05\x00\x01\x91\xd5\x8b]<\x8cMj\xc30\x10F\xd7\x9aSLgeC\xab\xd0.[\xbchM\xba,!7P\xe4\x91:T?A\x92M\xc0\xf8\xee%)\xcd\xea\x83\xef=\xde\xd9\xd8\x1f\xe3\x19m\x9c\x00$\x9esi\xd8\x81\"\x17\x1b\x81\xa2\\	@\x91\x97\xf6=\x9f\xb4\xcdq\x17$\xb0\xe4\xdb\xec\x96\x17\x82\x1e`1\x05\xad\xf3\x9f\x12\x18k+\x92\xfc\xdfw\xcc\xb9\x8dq\xc2\x01\xaf\xb6\xfe0\x95\xc7\x1c\xa3ISG\xeb\x8a\xfa\xcbD\xc6m\xa3G\xa4w\xf4\xc7\xc3\x88'Sy\xc2\xcae\x11\xcb\xd4\x03\xb89Y\xdc_\xd8\xce\x8d\xbb\x1eWP\xe2\x90K\xc1\xd7\xe1?\xaf\xef\xf8\xedF\x1e\x06L\x12\xae\xaar\xb1\xe9C\x91\xd4B\xea\xb8\x94\x1e\x94\xcaU\xef/\xd2\xba\xa7\xe7\x1e\xd4\x06\x1b\xfc\x06\x00\x00\xff\xffPK\x07\x08w+\x85(\xca\x00\x00\x00\x01\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00$\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00cmd_up.tmplUT\x05\x00\x01\x95\xd5\x8b]l\x91\xbf\x8e\xdc \x10\xc6k\xe6)F.Nv\xe4`]\xd2\xadrEdE\xd1\x15w\x8a\xec\xa4\x8aR``mt\x06,\x066:Y~\xf7\x88\xf5n\xb4\xf9C\x01\x1af\xe6\x9b\x8f\x1f\x8b\x90/b\xd4(\xad\x020v\xf1!b	xY\x85\xa7\x02X\xe1\xa9!3:1\xe7\x80^I\x8ay.\x00X1\x9a8\xa5\x81Ko\x9b\xd9\xcc\xda\xf8fI\x03\xa5\xa19\xbd+\xfe\x9b\xce\xc7\xbfIZ\x8e\xf7\xef\x1b\xe9\x87 rf]\x91?y\x95f\xfd,\xac\xc6mk(\x0d$\x83\x19t\xa0\x02*\x80\x93\x08\x98\x96\xd6*|\xc0\xbbs\x1fo\xbd\xb5\xc2\xa9\x15\xd87\xd2\x87\xec=-E\x0d\xac\x9f|\x88\x87\x1caH\x8ep\xf0q\xc2\xeeK\x8b\xa4\xc3\xc9H\x9dk\xba\xe4\x0exLN\x96\xd2*|\xf3\x87`\x8d\"\x8c\x84\xdf\x7fP\x0c\xc6\x8d\x15\xae\xc0\x98\xc4\xc3\x03Z\xf1\xa2K9	\x87\x9ex\x7f\xe6S\xe3}\x05\x8c\xed\xb0\xf8\xb3\x8f\xe6\xf8Z\xca:\x17<\xba\xa8CHK\xac\xf1B\x90\xf7\x8f\x9f\xbf~\xea\x9e*\x00\xc6F\xbf\x1b\xd8\xf5Y\xe6\xc4\xbb\xe4\xca,\xb7\x9d\xf7\xbf*v\xd0\xbc\xbf\x92)\xefn \xf1\x8c\xb0\x15V\xcf\xad\xa0+\xc5~\x7f\xf0\xef\x8e\xb0nWu`\xec\xc3[	\x97\xb9\xfd\x94\xa2\xf2?\xf7\xe1\xd797w[\x0d\x1b@6\x83\xc6\x99\xb8;\xea\xbc\x8f\xadU\xfc\xa3R\x17r\xe5\xf9\x87*\xd8\xe0W\x00\x00\x00\xff\xffPK\x07\x08\xd8\xec\xc5\xadr\x01\x00\x00e\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00gitignore.tmplUT\x05\x00\x01\xe0a\xed\\J*\xcd\xccI\xd1\xe7\xd2s	\x8e\x0f.\xc9/J\xe5\x02\x04\x00\x00\xff\xffPK\x07\x08\xe4\xa5\xd4\x89\x17\x00\x00\x00\x11\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00+\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00go-mod.tmplUT\x05\x00\x01\xa3\xd5\x8b]\xca\xcdO)\xcdIU\xa8\xaeV\xd0\xf3\x053\xfd\x12sS\x15jk\xb9\xb8\xd2\xf3\x15\x0c\xf5\x0c\x8d\xb9\x00\x01\x00\x00\xff\xffPK\x07\x08\xffkCw%\x00\x00\x00\"\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00proto.tmplUT\x05\x00\x01\xe0a\xed\\t\x8e\xb1\xca\xc20\x14\x85\xf7\xfb\x14\x87N\xed\xf2\xc3\x8fc\xe8\xd4\xc1I\x07_@B{)\xc16\x89\xb9\xa9(!\xef.Q\x8b\"\xb8\x9e\xef\xdc\xf3]\xb9\xd9\xa8\xafhQ\xf9\xe0\xa2\xdbT\x8a\x9c\x8f\xc6Y\x8c\xee\xe8u\x7f\xd2#\x17\x9a\x12\xfevnX&\xde\xeb\x99\x91s\xa5h\xc5\x85\xbdRE4\xb3HI\xb7\x1c\x0f|^X\"\x12\x01\x12\x83\xb1#\xcc\x80\x16\xff\x8a\xf2WQ\xbc\xb3\xc2?\x9a\xc2\xe1b\xfa\xa7\xa8\xd33O\x9d\x96\xf5\x8f\xc7I\xf0}\x99\xa9\xdf\xce\x06\x81\xe3\x12\xac\xa0\xfe\xd8o\x902e\xba\x07\x00\x00\xff\xffPK\x07\x08\x84\x01\x0dr\x9e\x00\x00\x00\xf5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00server.tmplUT\x05\x00\x01\xe0a\xed\\*HL\xceNLOU(N-*K-\xe2\xe2\xca\xcc-\xc8/*Q\xd0\xe0\xe2T\xaa\xaeV\xd0\xf3\xcdO)\xcdI\xf5K\xccMU\xa8\xadU\xe2\xd2\xe4\xe2\xe2*\xa9,HU\x00\xc99'\xe6\xa6\xe68'\x16\xc3\xa4\x83\xc1F(\x14\x97\x14\x95&\x97(Tsq\x82\x14A\xe5\xf4pk\xe0\xaa\xe5\x02\x04\x00\x00\xff\xffPK\x07\x08\xa1\x1b\xbf\x91a\x00\x00\x00\x85\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x003\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00server_test.tmplUT\x05\x00\x01\xb2\xd5\x8b]t\x90\xb1j\xc30\x10\x86g\xddS\x1c\x9e\xa4\x10\x14\xe8X\xc8d:&C\xda\x17P\x9d\xebUT\x96\x8c$'\x05\xe3w/g'\xd0B3i\xf8?}\xf7\xdf\x0d\xae\xfbrLX(_(\x03\xf8~H\xb9\xa2\x06\xd5\xa4\xd2\x80j*\x95\xea#7\x00\xaa\xe1\x948\x90\xe5\x14\\d\x9b2\xef8\x0f\xdd\x1a\xf9\xfa9\xbe\xdb.\xf5\xbb\xe0\x03\xf9\xb4<\xbb\xcb\x938\xa6	\xed!\x9d\xc7@G\xd7\x13\xces\x03\x06\xe0\xe22\x16\xdc\xa3\xa4\xad\xeb)\xb4\xae\xdc\x81\xd7\xa5\xce4/P\x17\xfc\x02\xdd2\xfb\xdf\x876x\x8a\x15\xe0c\x8c\x1d\xbeQ\xa9\x07\xe7\xa3\xeeqs\xebo\x0f\x06'P\xbe\x1f\x02>\xefQ0\xcd\xb8\x91\xfev\x1d\xb6\xe4\xea\xf7\x9c\x13\xb1/\x95\xf2\xe3\x82\x9a\xb7X\x0c\xa8\x19@q\x11\xf1\"<\xd2\xf5\x96\x9bu\xa4\xe6b\x00\x94;\x9f\xf3v=\xb5\xb0r!a\xa5\xee\xdd'6N+\xa3\xe5\x8f,\xbf\xff\xb3\xfe\x91\xae\x8f/\xa0\x17\xa9\x18\xdb\x14\xa3\x96\x89F4\xa9\xd8\x97o_uoOc\xd4\xc6\xc0\x0c?\x01\x00\x00\xff\xffPK\x07\x08\xc5\x07\xd0\x9d\x18\x01\x00\x00\xfb\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x005\xa89O\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00subscribers.tmplUT\x05\x00\x01\xb6\xd5\x8b]\x94\x911\x8f\xdb0\x0c\x85\xe7\xe8W\x10\xeeb\x07\x81\xd4v\xf4vp\x87.i\x06w\x0fd\x85u\x84\xb3D\x95\xa2\x0ew0\xfc\xdf\x0b\x9f}\xd9\n\xb4\x93@>\xbe\xc7\x0fb\xb2\xee\xd9\x8e\x08\xb9\x0c\xd9\xb1\x1f\x90\xb3R>$b\x81Z\x1d\xaa\xd1\xcb\xbd\x0c\xdaQ0\x93\x9f\xd0\x93Ie\xc8e0/_+\xd5(%o	a\x9eAw6\xe0\xd4\xd9\x8c?l@X\x96\x1e\xf9\xc5;\xec\x1f\xb9\x90\x85\x8b\x13\x98\x17\xa5~\x95\xe8\xa0\xcep\xfc'g\x03=JI\xb5\x83\xe3\xb6\\w\x93\xc7(\x0d\xcc\xea`\x0c\xdcERn\x8d\x19\xe9FN\x13\x8f\xe6o\xd4\x9f6\xa3\xbe\xc4w\xa3\xd3\x97X\xef\x91\xdfm\xbcM\xc8\x97$\x9eb\xde\x82\x0f?)y\xd7\x02\x00T\x99\x02^e\xad\xab\xd3&\xae\xb8\xef\x1aTyC\xbeF\x1b\xf0C\xde\x03[\x80\xac{\nxF\xb9\xd3m\x17\xbf\xa1\xbdM>b\x0b_>\xc3\x11\xc4\x07\xd4=:\x8a\x1f\x03\x1dEW\x981\xba\xb7uf\xef>\x15\xa1'\xf7\xdc\x02\x08\x17\xdc\x9aK\xa3\x16\xa5\x8c\x81\xff\xfe\xd4\x07T\xed\xe4\x15\x1cE\xc1W\xd1\xdd\xf6\x9e\x80\xf17\x1c\x13\x93\x90>c\xcev\xc4\x13\\\x1f'8\xe7\xb1\x01d&\x86y\xdd~`\x94\xc2\x11\xa2\x9f\xd6rQ\x7f\x02\x00\x00\xff\xffPK\x07\x08:\x0c!BM\x01\x00\x00Z\x02\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcNf\x96,\xd5#\x01\x00\x00\xf1\x01\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00Dockerfile.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x1e\x06\xed\xa9\xa8\x00\x00\x00\xdd\x00\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81i\x01\x00\x00Makefile.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x19\xa89O\xe4\xe99\xbd\xdf\x01\x00\x00\x07\x04\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81U\x02\x00\x00client.tmplUT\x05\x00\x01\x83\xd5\x8b]PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x1d\xa89O\xf2\xc9d\xa2[\x01\x00\x00\xc0\x02\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81v\x04\x00\x00cmd_main.tmplUT\x05\x00\x01\x8a\xd5\x8b]PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\"\xa89Ow+\x85(\xca\x00\x00\x00\x01\x01\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x15\x06\x00\x00cmd_root.tmplUT\x05\x00\x01\x91\xd5\x8b]PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00$\xa89O\xd8\xec\xc5\xadr\x01\x00\x00e\x02\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81#\x07\x00\x00cmd_up.tmplUT\x05\x00\x01\x95\xd5\x8b]PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\xe4\xa5\xd4\x89\x17\x00\x00\x00\x11\x00\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd7\x08\x00\x00gitignore.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00+\xa89O\xffkCw%\x00\x00\x00\"\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x813	\x00\x00go-mod.tmplUT\x05\x00\x01\xa3\xd5\x8b]PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\x84\x01\x0dr\x9e\x00\x00\x00\xf5\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9a	\x00\x00proto.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xaa\x83\xbcN\xa1\x1b\xbf\x91a\x00\x00\x00\x85\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81y\n\x00\x00server.tmplUT\x05\x00\x01\xe0a\xed\\PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x003\xa89O\xc5\x07\xd0\x9d\x18\x01\x00\x00\xfb\x01\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1c\x0b\x00\x00server_test.tmplUT\x05\x00\x01\xb2\xd5\x8b]PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x005\xa89O:\x0c!BM\x01\x00\x00Z\x02\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81{\x0c\x00\x00subscribers.tmplUT\x05\x00\x01\xb6\xd5\x8b]PK\x05\x06\x00\x00\x00\x00\x0c\x00\x0c\x00.\x03\x00\x00\x0f\x0e\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00Dockerfile.tmplUT\x05\x00\x01\x80Cm8tP\xc1n\xea0\x10\xbc\xfb+V9pz\x8e9>=)\x07\x1eP\x84Zb\x14\xa8*DQelc\xa2&\xb6e;m\xa5(\xff^\xe1FP\xa8z\x9b\xdd\x99\xdd\x9d\xd9\xbb\x82.@\x99\x8ai\xf5\x8fU\xb6\xd4\x12F+\xd87e%\xb0\xd4o\xe8\x89\x16\xf7\x93y\x01\xa4m!\xcdY-\xa1\xebP\xf1\x98\x03\xb3\xaf\xd0X\xc1\x82\x84\xc1\xa0\xaf\x94c\"\x96\xcf\x08\x00b\x93	\x01\x18k\x839\xe3G	{\xe6\x8f\xa0\xca\x00\xc6J\xed\xfd\x11\x8d\xe9r\x03\xca\xa4\xb5\x11W7\xc8W\xef\xcc\xfb\xa6\xfe\xc1\xfb\xa6\x8eV\x94\x81\xd3\xb80\xef\xba2\xac\x9fI\xaf\xe4Q7\x9e\xd1\x97i>\xfa\xff0\x9ddC\x98Q\xba\xca\xaaR7\x1f\xa7\x0511\xe0\x1e\x90\xb6\x8da\xbb\x0e\xd2\x0bF\x08\xc5wy\xeeX\xe0\xbdw\x8c\x0f\xce\xd4\xd9\xf9c@d\xe0\xc4\xfb\x8ap\xe9\x82'\x9c\xe1\x13(\x0f%gA\xfa\x94\xbbp+\xf9m\xd1\xf7\xb8\xb7\xb6\x08\x9a\xe6\xebb\xb3\xa4\xf3|\x0d\xdb\xe4\xc2$;4^L`\x9b46\xf9\x03	\xc6\xcaY\x8e\xadq!\xfb;Lv\xe8s\x00PK\x07\x08f\x96,\xd5\"\x01\x00\x00\xf1\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00Makefile.tmplUT\x05\x00\x01\x80Cm8L\x8f\xb1j\xc3@\x0c\x86\xe7\xdcS\x08\x1aH\x0b\x95L;\x1a\xbcuh\x97\xb4k\xa7\xc41\x8ar\xf4l\x99;\xd9\xcbq\xef^|\xe9\xd0E\xfa~$~\xf8\x1e`\xf5-\\\xad\x1b\xfb\x1fv\xf4\xf5\xfey\xfcna\x8ej\n\xc6\xc9\x9c\xab\xdc\xba\x9d(\x08\x1b\x88\xb7\xdbr\xa1A\xc7F4\xf4\x934\xf5\xe1\xb2\\\xef0\xa0\xf0\x84\xa2nw\x8f\x80\x1f@\xdb\xd8\xef\x1fE!\xf8d\x80#\xe0\x15\x0e9\x9fs\xa67\x1fK9\x97r\xf8_\x1d|`\xafu5\xeb\xeb\x13\xe4L\xc7~\xe4R\xa8\xb6\x02\xe2v\xc2\xc4q\xe5x\xd2\xc5:\x02D\xd1\x8asX\xc4O\xa9\x938\x0f\xcfso\xb7\xd4%]\xe2\xc0\xa7\xc8\xa17\xbfrK\xcemv\x7f\xa6Un\xcb\x803\xbc\x00\xae@\x0d\x11\xb9\xdf\x01\x00PK\x07\x08\x08\x98;\x05\xcf\x00\x00\x00\x1d\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00client.tmplUT\x05\x00\x01\x80Cm8\xbcT\xc1N\xdb@\x10=\xef|\xc5\xd4\x87\xd6\x96\xc2F\xea\xb1RNAE\xa8\x14$ \xe2\x88\x96\xf5\xe0\xacX\xefX\xe3\x0d`E\xf9\xf7jmC\x0d(\x14.\xddKb\xcf\xbc\xe77of\xb61\xf6\xceT\x84\xdb-\xeaSS\x13\xeev\x00\xaenX\"\xe6\xa0\xb2\xb6\x0b6\x03PY\xe5\xe2zs\xa3-\xd7\xf3J\x1a{@\x96\xdb\xae\x8d4>rC!\x8a\xb1.T\xf3\x8a\xe7\x1cSR\xf6\x12\xe7\x9d'\xc7\xfd\xcf\xfc\xfe{\x06j\x02\xc2i\xe2\x94l\xf2\xff\xa0\xe2\x0cp<\x89\xff\xbave\xe9\xe9\xc1\x08\xe1{\x02\xf9\xa0\x7f\xf37\xbb\x17\xc6\\y\xd2\x15{\x13*\xcdR\xf5\x85dP\x00\xdc\x1bI\xc5\xdb\x1a\x17\xf859\xa0\x7fo\"=nw\xa0\x96\xdeQ\x88\xbd]KS\x93_\x9a\x96F\xdf\x86P\xc2\xdfn\x82\xc5#\x8a\xfb\xb3\xf2\xe2\x1d\n\xdc\x82\xb2\xb5>a{\x97\x17\xa0J\xba%A[\xebU\xf0\xc3+P\xee\x16G%_\x16\x18\x9cO\x10%\x147\x12\xc6\x00\xa8\x1d\x80jI\xee\x9d\xa5\xd5\xf9	\xfeX`r^\xaf\xceO~\xb2\\\x0c\x81<K2\x0eO/F\x01Y\x01\xa0\xe6s\xbc\",9|\x8b\x18\x88J\x8c\x8c$\xc2\x82k\x12\x9a\xa1i1\xae]\x8bV\xc8Dj\xd1`\xc3\xec\xd1\x84\x12-\x87@6:\x0em\xcf\xf3\xe0\xbc\xc7\xb5i\x1a\n\xe8M$\x01\x95Rfx\x9d\xf4$\xc3\xf5\xa13>\x075\x91:\x03\xa5z\xa9C%\x97bB\x9b\x06\xf2\xacI\xc4y\x91\xe2=\xf4\xca\xc5\xf5*\x18\xe9\x8eC$\xb1\xd4D\x96\xfcyB^\x9fW\x13\xa3\x97k\xe3B\x0f\x1f{\xb2\x17\x99\xce \x88C\xa4\xc78\xe4O?Z\xcc\xfe\x0d>.\xa9n8R\xb0\xdd/\xea>\xcd1\xac\x94>k(\\\x0e\x8b\xf6\x96b\xb2,\xfa\xc8\xf3\x8d\xf1)\x95$/>\"p@<MF\xa1/\xc9SMQ:=q\xe9\x83U\xf7Mz\xd9\xa9\x8b(d\xea)\xfes\xad\x1a\xf0\x1f\xe9\xd5[\xab\xa6\xd8\xffa\xd8\xbe\xef\xbd\xc3\xda\x9bU\xa4\xfd\xb3\xde\xa5\xed8\xa5\x87\xfdwD\x9e\xd6\xa8x\xbe\x8f\x16h\xbd\x83\xa7\x1b\xc0z\x07;\xf83\x00PK\x07\x08\xa5\xb9\x0f\x95&\x02\x00\x00\xdb\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00cmd_main.tmplUT\x05\x00\x01\x80Cm8t\x92\xcfj\xdc0\x10\xc6\xcf\x9a\xa7\x18|\x08r	2\xf4\xb8\x90C\xd9\xa6\xb9t\xcb\x92\xed\x0b\xc8\xf6\xacVT\xb2\x16\xfdq\x1a\x8c\xdf\xbd\xc8\x7f\x92-xO\xb6\xbe\x9f\xe6\xfb4\xc3\\e\xf3G*B+u\x07\xa0\xed\xd5\xf9\x88\x1cX\xa1t\xbc\xa4Z4\xceVF\x1b\xd2n\xfaT\xfd\xd7b\x1b:\xe5\xb7\xc9\xd9;K]\xbf\x0d\xaf\xa9\x0e\xa9\xbe\xeb\xfa\x81+\xab\xdb\xd6\xd0\x9b\xf4T\xb5t\x96\xc9\xc40\x958\xa7\x0c	\xe5\x8c\xec\x94p^U\xca_\x9bL\x86\x01\xc5\xc1\xb5\xc9\xd0/i	\xc7qS\xac\x02\xf9\x9e\xfc6\xcb\x16\xeb\x7fc\xdb\x02J\x80s\xea\x9aiX\xbc\xc4\x01Xn[\x9c(\xfe\xa4\x9e\xcc\x0f\xef\xecs\xd7\xf3\x12X\xc0\xdd\x13>\xcc\xe6\"\xfb\xec\xa5%\xb3\x97a}\xcdiB\xc3\x08\xc0r\xafS\x0e/n\x12\x8br!\xf3M\x9e\x83\xb9\xc2/\xb9\xbfE\x9b^\xc0nj\xc4+)\x1d\"\xf9\xfb\x89\\=b(\x81\x8d\xab\xfd\x8bq\xb54\xd9P7\xc4K\xf1\"#\xbd\xc9\xf7W\x97\"\x05|\xc2\xa5\x87\xff\xe4;\xa5\xdfR\xbc\x88\xa33\xbay\xff,\xcc\xe2\xac\xdd\xa9\xfaN\xb25\xba\xa3 \x0e\x14/\xae\xbd	\x9d\x85\xdf\xda\x92K1\x00\xb0y\x1f\xf2\xc0\xf7FS\x17\xf9\xc3\xa2\xcc\xc7\x01\x18[:\xc9\xf3\xdc\xe1f`F\x8f\xc0\xd8\xd1\xbb^\xb7\xe4w\x88\x88\xcb\x96\x8ac\xaaO\xa9^\x11/\xf3\xc5\xc3\xc7\xee\xed\x10\xd7\xed\x13\x9f\xea\xe34N`\x8dm\xc5\xf3_jR$^\xc2\x08\xff\x06\x00PK\x07\x083S\x92}\x87\x01\x00\x00\\\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00cmd_root.tmplUT\x05\x00\x01\x80Cm8<\xccOK\x031\x10\x05\xf0s\xe6S\x8cs\xda\x80\xa6\xe8Q\xe9A\x97z\x94\xd2o\x90f'q0\x7fJ\x92]\n\xcb~w\xb1\xa2\xa7\x07\xef\xf7x\x17\xeb\xbel`ti\x02\x90t)\xb5\xe3\x00\x8a|\xea\x04\x8aJ#\x00EA\xfa\xe7|6\xae\xa4]\x94\xc8Rn\xb1[\x9e\x084\xc0b+:\x1f\xde%2\xb6^%\x87\xdf\xeeTJ\x1f\xd3\x84{\xfcY\x9b7\xdbx,)\xd9<\x0d\xb4\xaeh>lb\xdc6\xbaGz\xc5p:\x8ex\xb6\x8d'l\\\x17qL\x1a\xc0\xcf\xd9\xe1\xe1\xcan\xee<h\\A\x89G\xae\x15\x9f\xf7\x7f\xf7\xe6\x9f_nr\xb7\xc7,\x11WP\xca\xa7n\x8eUr\x8fy\xe0Z5(U\x9a9\\\xa5\x0f\x0f\x8f\x1a\xd4\x06\x1b|\x0f\x00PK\x07\x08w+\x85(\xc8\x00\x00\x00\x01\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00cmd_up.tmplUT\x05\x00\x01\x80Cm8l\x92\xcdn\xdb:\x10\x85\xd7\xe4S\xcc\xd5\"\xa0.T\niwn\xb3(\xd4\xc0\xc8\"A`\xb7\xab\xa2\x0b\x8a\xa2d\"$G\xe0\x8f\xeb\xc0\xd0\xbb\x17\x14\xed\xa2A\xbb\xf1\x80\x9c9\x87g>k\x16\xf2EL\n\xa4\x1d(\xd5vF\x1f\x81QRItQ\x9dbEI5\xda\xb5`(\xbfm\xd0\x93\x13&\x1f\xc2k\x90\xc2\x98\x8aRRM:\x1eR\xcf%\xda\xd6h\xa34\xb6s\xeaC\xea\xdb\xe3\xfb\xea\x9f\xed<\xf5w3\xcc\xe3\xed\x87Vb\xefE\xee\x9c\xcf\xc0\x1fqHF=	\xab`Y\xda\x90\xfa \xbd\xee\x95\x0f\x15\xad)=\n\x0fi\xee\xec\x00wp\xb3\xeax\x87\xd6\n7\x9c)\xf9\x16\xd4\x06\x00\xaa4W\x0d%\xfb\x03\xfa\xb8\xc9'\xf0\xc9\x05\xe81\x1e`\xf7\xdcAP\xfe\xa8\xa5\xca3\xbb\xe460&'\x99\xb4\x03\xfc\xff\xc6\xb0\x01\xe1\xa7\x00\xdf\x7f\x84\xe8\xb5\x9bj8SB$l\xee\xc0\x8a\x17\xc5\xe4A8\xc0\xc0\xf7+\x9f\x06nkJH\x81\xc5\x9f0\xea\xf1\x95\xc9&\x0f<\xb8\xa8\xbcOsl\xe0B\x90\xef\x1f\xb6_\xefw\x8f5\xa5\x84d0|k\xb0\x17f_\x82\xb1\x9ao\x91U\x05h\xd5\\\xf2\xc5\x13\\\xfe%\xde\x95Z\x83\xf2\x1e\xfd\x9a\x8bL\x08E\xc0\xf7Wd\xec\xe6\x0fz<\xb3\xed\x84U\xa6\x13\xe1\x8a\xf7\xf2\xe0o\x85?/k&\xf2\xe9\x9d\x8c'\xfe\x05\x9dby-r\xb5>\xa48\xe0OW.\xbd\x8a\xc9;p\xdaPB\x8ap\xc2\x92\xb6\xc0\xca6\xb9\xac+\xbe\xd1.l\x1d\xd7c^!#]Gv\xc9\xb1\xfa\xe3z\xf5\xdf]\xf6-.\xa3\x8d\xfc\xd9k\x17\x8dc\xca\xfb\xac'\x18\xf8\xfdIG\xb6R_(Y\x1a\xbaP\x9a\xdf\x06\xedt,\x01v\x88\xb1\xb3\x03\xff<\x0c\x97\x8f\x84\xa5\xb9\xb3CM\x17\xfak\x00PK\x07\x08\x84]\x13\xd1\xcf\x01\x00\x00\n\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00gitignore.tmplUT\x05\x00\x01\x80Cm8\x00\x11\x00\xee\xffbuild/\n.DS_Store\n\x03\x00PK\x07\x08\xe4\xa5\xd4\x89\x18\x00\x00\x00\x11\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00go-mod.tmplUT\x05\x00\x01\x80Cm8\x00\"\x00\xdd\xffmodule {{ .ModuleName }}\n\ngo 1.13\n\x03\x00PK\x07\x08\xffkCw)\x00\x00\x00\"\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00proto.tmplUT\x05\x00\x01\x80Cm8t\x8e\xbd\n\xc20\x14\x85\xf7\xfb\x14\x87Nv\x11\xc41t\xea\xe0\xa4\x83/ \xa1\xbd\x94`\x9b\xc4\xdcT\x94\x90w\x97X\x8b \xb8\x9e\xef\xfc\xc9\xd3F\xfd@\x83\xca\x07\x17\xdd\xbeR\xe4|4\xcebp\x17\xaf\xbb\xab\x1e\xb8\xd0\x94\xb0=\xba~\x1e\xf9\xa4'F\xce\x95\xa2\x15\x17\xf6Q\x15\xd1\xc4\"%t\xe0x\xe6\xdb\xcc\x12\x91\x08\x90\x18\x8c\x1d`z4\xd8)\xca?F\xf1\xce\n\xffq\n\x87\xbb\xe9\x96\xa1VO<\xb6Z\xd6\x1f\xefH\xf0]\xa9\xd9|7k\x04\x8es\xb0\x82E\\\xfak\xa4L\x99^\x03\x00PK\x07\x08\x84\x01\x0dr\x99\x00\x00\x00\xf5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00server.tmplUT\x05\x00\x01\x80Cm8*HL\xceNLOU(N-*K-\xe2\xe2\xca\xcc-\xc8/*Q\xd0\xe0\xe2T\xaa\xaeV\xd0\xf3\xcdO)\xcdI\xf5K\xccMU\xa8\xadU\xe2\xd2\xe4\xe2\xe2*\xa9,HU\x00\xc99'\xe6\xa6\xe68'\x16\xc3\xa4\x83\xc1F(\x14\x97\x14\x95&\x97(Tsq\x82\x14A\xe5\xf4pk\xe0\xaa\xe5\x02\x0c\x00PK\x07\x08\xa1\x1b\xbf\x91^\x00\x00\x00\x85\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00server_test.tmplUT\x05\x00\x01\x80Cm8t\x90\xb1j\xc30\x10\x86g\xddS\x1c\x9e\xa4\x10\x14\xe8X\xc8d:&C\xda\x17P\x9d\xebUT\x96\x8c$'\x05\xe3w/g'\xd0B3i\xf8?}\xf7\xdf\x0d\xae\xfbrLX(_(\x03\xf8~H\xb9\xa2\x06\xd5\xa4\xd2\x80j*\x95\xea#7\x00\xaa\xe1\x948\x90\xe5\x14\\d\x9b2\xef8\x0f\xdd\x1a\xf9\xfa9\xbe\xdb.\xf5\xbb\xe0\x03\xf9\xb4<\xbb\xcb\x938\xa6	\xed!\x9d\xc7@G\xd7\x13\xces\x03\x06\xe0\xe22\x16\xdc\xa3\xa4\xad\xeb)\xb4\xae\xdc\x81\xd7\xa5\xce4/P\x17\xfc\x02\xdd2\xfb\xdf\x876x\x8a\x15\xe0c\x8c\x1d\xbeQ\xa9\x07\xe7\xa3\xeeqs\xebo\x0f\x06'P\xbe\x1f\x02>\xefQ0\xcd\xb8\x91\xfev\x1d\xb6\xe4\xea\xf7\x9c\x13\xb1/\x95\xf2\xe3\x82\x9a\xb7X\x0c\xa8\x19@q\x11\xf1\"<\xd2\xf5\x96\x9bu\xa4\xe6b\x00\x94;\x9f\xf3v=\xb5\xb0r!a\xa5\xee\xdd'6N+\xa3\xe5\x8f,\xbf\xff\xb3\xfe\x91\xae\x8f/\xa0\x17\xa9\x18\xdb\x14\xa3\x96\x89F4\xa9\xd8\x97o_uoOc\xd4\xc6\xc0\x0c?\x03\x00PK\x07\x08\xc5\x07\xd0\x9d\x15\x01\x00\x00\xfb\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x00subscribers.tmplUT\x05\x00\x01\x80Cm8\x94\x911o\xdb0\x10\x85g\xf3W<\xa8\x8bd\x18d\xdbQ[\xa0\x0e]\\\x0f\xeanP\xf4U&\"\xf1X\xf2\x18$\x10\xf4\xdf\x0bG\x8e\xb7\x02\xedD\xf0\xde\xbd\xef\x1ey\xd1\xbag;\x12r\x19\xb2K~\xa0\x94\x95\xf2s\xe4$\xa8\xd5\xae\x1a\xbd\\\xcb\xa0\x1d\xcff\xf2\x13y6\xb1\x0c\xb9\x0c\xe6\xe5k\xa5\x1a\xa5\xe4-\x12\x96\x05\xba\xb33M\x9d\xcd\xf4\xc3\xce\x84u\xed)\xbdxG\xfd\x83\x8b,\xa98\xc1\xb2*\xf5\xab\x04\x87:c\xffO\xce\x06=I\x89\xb5\xc3~\x1b\xae\xbb\xc9S\x90\x06\x8b\xda\x19\x83\xabH\xcc\xad1#_\xd8iN\xa3\xf9[\xeaO\x9bQ\x9f\xc2\xbb\xd1\xe9S\xa8\xef\xc8\xef6\\&J\xa7(\x9eC\xde\xc0\xbb\x9f\x1c\xbdk\x01\xa0\xca<\xd3Yn\xf7\xea\xb0\x89\xb7\x87\xbek\xa8\xf2\x16\xf9\x1c\xecL\x1f\xf2\x1d\xd8\x02Y\xf7<\xd3\x91\xe4\xca\x97\xbb\xf8\x8d\xece\xf2\x81Z|\xf9\x8c=\xc4\xcf\xa4{r\x1c>\x1a:\x0e\xae\xa4D\xc1\xbd\xddz\xee\xd5\xa7\"\xfc\xe4\x9e[@R\xa1\xad\xb86jU\xca\x18\xfc\xf7\xa7>B\xd5N^\xe18\x08\xbd\x8a\xee\xb6\xf3\x80D\xbf\xb1\x8f\x89\x85\xf5\x91r\xb6#\x1dp~\xac\xe0\x98\xc7\x06\x94\x12',\xb7\xe9\xbbDRR@\xf0\x932\x06\xab\xfa3\x00PK\x07\x08:\x0c!BK\x01\x00\x00Z\x02\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(f\x96,\xd5\"\x01\x00\x00\xf1\x01\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x00\x00\x00\x00Dockerfile.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x08\x98;\x05\xcf\x00\x00\x00\x1d\x01\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81h\x01\x00\x00Makefile.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xa5\xb9\x0f\x95&\x02\x00\x00\xdb\x05\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81{\x02\x00\x00client.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(3S\x92}\x87\x01\x00\x00\\\x03\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xe3\x04\x00\x00cmd_main.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(w+\x85(\xc8\x00\x00\x00\x01\x01\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xae\x06\x00\x00cmd_root.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x84]\x13\xd1\xcf\x01\x00\x00\n\x03\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xba\x07\x00\x00cmd_up.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xe4\xa5\xd4\x89\x18\x00\x00\x00\x11\x00\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xcb	\x00\x00gitignore.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xffkCw)\x00\x00\x00\"\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81(\n\x00\x00go-mod.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x84\x01\x0dr\x99\x00\x00\x00\xf5\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x93\n\x00\x00proto.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xa1\x1b\xbf\x91^\x00\x00\x00\x85\x00\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81m\x0b\x00\x00server.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xc5\x07\xd0\x9d\x15\x01\x00\x00\xfb\x01\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x0d\x0c\x00\x00server_test.tmplUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(:\x0c!BK\x01\x00\x00Z\x02\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81i\x0d\x00\x00subscribers.tmplUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x0c\x00\x0c\x00.\x03\x00\x00\xfb\x0e\x00\x00\x00\x00"
 	fs.Register(data)
 }
@@ -0,0 +1,344 @@
+package lile
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const instrumentationName = "github.com/lileio/lile/v2"
+
+// Telemetry configures OpenTelemetry tracing and metrics for RPCs. Any
+// provider left nil falls back to the global one registered with
+// go.opentelemetry.io/otel, and the propagator defaults to W3C trace context
+// and baggage. A nil *Telemetry uses the globals too. Streaming RPCs get an
+// event on their span for every message sent and received
+type Telemetry struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// rpcTelemetry holds the instruments for one side of an RPC
+type rpcTelemetry struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+}
+
+func (t *Telemetry) instruments(side string) rpcTelemetry {
+	tp, mp, prop := otel.GetTracerProvider(), otel.GetMeterProvider(), propagation.TextMapPropagator(
+		propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if t != nil {
+		if t.TracerProvider != nil {
+			tp = t.TracerProvider
+		}
+		if t.MeterProvider != nil {
+			mp = t.MeterProvider
+		}
+		if t.Propagator != nil {
+			prop = t.Propagator
+		}
+	}
+
+	duration, err := mp.Meter(instrumentationName).Float64Histogram(
+		"rpc."+side+".duration",
+		metric.WithUnit("ms"),
+		metric.WithDescription("Duration of RPCs"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return rpcTelemetry{
+		tracer:     tp.Tracer(instrumentationName),
+		propagator: prop,
+		duration:   duration,
+	}
+}
+
+// UnaryServerInterceptor traces unary RPCs, continuing the trace propagated
+// by the caller, and records their duration
+func (t *Telemetry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	rt := t.instruments("server")
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span, attrs := rt.startServer(ctx, info.FullMethod)
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		rt.end(ctx, span, attrs, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor traces streaming RPCs, continuing the trace
+// propagated by the caller, and records their duration
+func (t *Telemetry) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	rt := t.instruments("server")
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, span, attrs := rt.startServer(ss.Context(), info.FullMethod)
+		start := time.Now()
+
+		err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx, span: span})
+		rt.end(ctx, span, attrs, start, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor traces outgoing unary RPCs and propagates the
+// trace to the server in the request metadata
+func (t *Telemetry) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	rt := t.instruments("client")
+
+	return func(ctx context.Context, method string, req, resp interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span, attrs := rt.startClient(ctx, method)
+		start := time.Now()
+
+		err := invoker(ctx, method, req, resp, cc, opts...)
+		rt.end(ctx, span, attrs, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor traces outgoing streaming RPCs and propagates
+// the trace to the server in the request metadata. The span ends when the
+// stream finishes or fails
+func (t *Telemetry) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	rt := t.instruments("client")
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+		method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span, attrs := rt.startClient(ctx, method)
+		start := time.Now()
+
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			rt.end(ctx, span, attrs, start, err)
+			return nil, err
+		}
+
+		return &tracedClientStream{
+			ClientStream:  cs,
+			serverStreams: desc.ServerStreams,
+			span:          span,
+			end: func(err error) {
+				rt.end(ctx, span, attrs, start, err)
+			},
+		}, nil
+	}
+}
+
+func (rt rpcTelemetry) startServer(ctx context.Context, method string) (context.Context, trace.Span, []attribute.KeyValue) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = rt.propagator.Extract(ctx, metadataCarrier(md))
+
+	attrs := rpcAttributes(method)
+	ctx, span := rt.tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, span, attrs
+}
+
+func (rt rpcTelemetry) startClient(ctx context.Context, method string) (context.Context, trace.Span, []attribute.KeyValue) {
+	attrs := rpcAttributes(method)
+	ctx, span := rt.tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	rt.propagator.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span, attrs
+}
+
+func (rt rpcTelemetry) end(ctx context.Context, span trace.Span, attrs []attribute.KeyValue, start time.Time, err error) {
+	code := status.Code(err)
+	attrs = append(attrs, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+
+	span.SetAttributes(attrs[len(attrs)-1])
+	if err != nil {
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+	span.End()
+
+	if rt.duration != nil {
+		elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+		rt.duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+	}
+}
+
+// rpcAttributes splits a full method name, /pkg.Service/Method, into the
+// semantic convention attributes
+func rpcAttributes(method string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+
+	name := strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		attrs = append(attrs,
+			semconv.RPCService(name[:i]),
+			semconv.RPCMethod(name[i+1:]),
+		)
+	}
+
+	return attrs
+}
+
+// messageEvent records a message sent or received on a stream, ids count
+// up from 1 in each direction
+func messageEvent(span trace.Span, kind attribute.KeyValue, id int, m interface{}) {
+	attrs := []attribute.KeyValue{kind, semconv.MessageIDKey.Int(id)}
+	if pm, ok := m.(proto.Message); ok {
+		attrs = append(attrs, semconv.MessageUncompressedSizeKey.Int(proto.Size(pm)))
+	}
+
+	span.AddEvent("message", trace.WithAttributes(attrs...))
+}
+
+// tracedServerStream carries the context holding the span to the handler
+// and records an event for each message
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	span trace.Span
+
+	sent, received int
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tracedServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		messageEvent(s.span, semconv.MessageTypeSent, s.sent, m)
+	}
+
+	return err
+}
+
+func (s *tracedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		messageEvent(s.span, semconv.MessageTypeReceived, s.received, m)
+	}
+
+	return err
+}
+
+// tracedClientStream records an event for each message and ends the span
+// once the stream is finished
+type tracedClientStream struct {
+	grpc.ClientStream
+	serverStreams bool
+	span          trace.Span
+	end           func(err error)
+
+	once           sync.Once
+	sent, received int
+}
+
+func (s *tracedClientStream) finish(err error) {
+	s.once.Do(func() { s.end(err) })
+}
+
+func (s *tracedClientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.finish(err)
+	}
+
+	return md, err
+}
+
+func (s *tracedClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil {
+		// io.EOF means the real error is returned by RecvMsg
+		if err != io.EOF {
+			s.finish(err)
+		}
+		return err
+	}
+
+	s.sent++
+	messageEvent(s.span, semconv.MessageTypeSent, s.sent, m)
+	return nil
+}
+
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		s.finish(nil)
+		return err
+	}
+	if err != nil {
+		s.finish(err)
+		return err
+	}
+
+	s.received++
+	messageEvent(s.span, semconv.MessageTypeReceived, s.received, m)
+
+	// Without server streaming the single response ends the RPC
+	if !s.serverStreams {
+		s.finish(nil)
+	}
+
+	return nil
+}
+
+// metadataCarrier adapts gRPC metadata for propagation
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
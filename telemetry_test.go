@@ -0,0 +1,165 @@
+package lile
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lileio/lile/v2/test"
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestTelemetry(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	tel := &Telemetry{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+
+	s := testService(t, "telemetry")
+	s.Telemetry = tel
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(), grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(tel.UnaryClientInterceptor()))
+	assert.Nil(t, err)
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+
+	server, client := spans[0], spans[1]
+	assert.Equal(t, "grpc.health.v1.Health/Check", server.Name)
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Equal(t, trace.SpanKindClient, client.SpanKind)
+
+	// The trace was propagated in the traceparent header
+	assert.Equal(t, client.SpanContext.TraceID(), server.SpanContext.TraceID())
+	assert.Equal(t, client.SpanContext.SpanID(), server.Parent.SpanID())
+
+	var rm metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &rm))
+
+	names := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			names[m.Name] = true
+		}
+	}
+	assert.True(t, names["rpc.server.duration"])
+	assert.True(t, names["rpc.client.duration"])
+}
+
+var echoDesc = grpc.ServiceDesc{
+	ServiceName: "lile.test.Echo",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Chat",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			for {
+				var a test.Account
+				if err := stream.RecvMsg(&a); err != nil {
+					if err == io.EOF {
+						return nil
+					}
+					return err
+				}
+
+				if err := stream.SendMsg(&a); err != nil {
+					return err
+				}
+			}
+		},
+	}},
+}
+
+func messageEvents(span tracetest.SpanStub) map[string]int {
+	events := map[string]int{}
+	for _, e := range span.Events {
+		for _, a := range e.Attributes {
+			if a.Key == semconv.MessageTypeKey {
+				events[a.Value.AsString()]++
+			}
+		}
+	}
+
+	return events
+}
+
+func TestTelemetryStreams(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tel := &Telemetry{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+	}
+
+	s := testService(t, "telemetry-streams")
+	s.Telemetry = tel
+	s.GRPCImplementation = func(g *grpc.Server) {
+		g.RegisterService(&echoDesc, struct{}{})
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(), grpc.WithInsecure(),
+		grpc.WithStreamInterceptor(tel.StreamClientInterceptor()))
+	assert.Nil(t, err)
+
+	stream, err := conn.NewStream(ctx, &echoDesc.Streams[0], "/lile.test.Echo/Chat")
+	assert.Nil(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		assert.Nil(t, stream.SendMsg(&test.Account{Name: name}))
+
+		var res test.Account
+		assert.Nil(t, stream.RecvMsg(&res))
+		assert.Equal(t, name, res.Name)
+	}
+
+	assert.Nil(t, stream.CloseSend())
+	assert.Equal(t, io.EOF, stream.RecvMsg(&test.Account{}))
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+
+	for _, span := range spans {
+		assert.Equal(t, "lile.test.Echo/Chat", span.Name)
+		assert.Equal(t, map[string]int{"SENT": 3, "RECEIVED": 3}, messageEvents(span))
+	}
+}
@@ -0,0 +1,282 @@
+package lile
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// certCheckInterval is how often certificate files are checked for changes
+const certCheckInterval = 5 * time.Second
+
+// TLSEnabled returns true if a certificate and key have been configured
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSCert != "" && c.TLSKey != ""
+}
+
+// MutualTLS returns true if client certificates are required
+func (c *ServerConfig) MutualTLS() bool {
+	return c.TLSEnabled() && c.TLSClientCA != ""
+}
+
+// ServerTLSConfig builds a TLS config for the server which reloads the
+// certificate, key and client CA from disk when they change
+func (c *ServerConfig) ServerTLSConfig() (*tls.Config, error) {
+	return c.serverTLSConfig(defaultLogger)
+}
+
+// serverTLSConfig builds the server TLS config, logging reloads to log
+func (c *ServerConfig) serverTLSConfig(log Logger) (*tls.Config, error) {
+	r := &certReloader{certFile: c.TLSCert, keyFile: c.TLSKey, caFile: c.TLSClientCA, logger: log}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		GetCertificate: r.getCertificate,
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2"},
+	}
+
+	if c.MutualTLS() {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cc := cfg.Clone()
+			cc.GetConfigForClient = nil
+			cc.ClientCAs = r.certPool()
+			return cc, nil
+		}
+	}
+
+	return cfg, nil
+}
+
+// TLSEnabled returns true if a root CA or a client certificate and key
+// have been configured
+func (c *ClientConfig) TLSEnabled() bool {
+	return c.TLSRootCA != "" || (c.TLSCert != "" && c.TLSKey != "")
+}
+
+// TLSConfig builds a TLS config for dialing a server. Servers are verified
+// against the root CA, or the system roots when it isn't set, and the
+// client certificate is presented when one is configured
+func (c *ClientConfig) TLSConfig() (*tls.Config, error) {
+	r := &certReloader{certFile: c.TLSCert, keyFile: c.TLSKey, caFile: c.TLSRootCA}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		RootCAs:    r.certPool(),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.TLSCert != "" {
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.getCertificate(nil)
+		}
+	}
+
+	return cfg, nil
+}
+
+// ClientTransportOption returns the dial option for connecting to other
+// services, using the ClientConfig of the global service. If the TLS
+// config can't be loaded every connection fails with the error, rather
+// than falling back to plaintext
+func ClientTransportOption() grpc.DialOption {
+	if !service.ClientConfig.TLSEnabled() {
+		return grpc.WithInsecure()
+	}
+
+	cfg, err := service.ClientConfig.TLSConfig()
+	if err != nil {
+		service.log().Error("lile: couldn't load client TLS config", "error", err)
+		return grpc.WithTransportCredentials(failedCredentials{err})
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(cfg))
+}
+
+// failedCredentials fails every handshake with the error that stopped the
+// real credentials from loading
+type failedCredentials struct {
+	err error
+}
+
+func (c failedCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn.Close()
+	return nil, nil, fmt.Errorf("lile: client TLS config unavailable: %v", c.err)
+}
+
+func (c failedCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn.Close()
+	return nil, nil, fmt.Errorf("lile: TLS config unavailable: %v", c.err)
+}
+
+func (c failedCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c failedCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (c failedCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// certReloader holds a certificate pair and CA pool loaded from disk and
+// reloads them when the files are modified, e.g. when they are rotated by
+// cert-manager or Vault
+type certReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	logger   Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	pool      *x509.CertPool
+	modTimes  []time.Time
+	lastCheck time.Time
+}
+
+func (r *certReloader) load() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		c, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return err
+		}
+
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		b, err := ioutil.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return errors.New("lile: no certificates found in " + r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = cert
+	r.pool = pool
+	r.modTimes = modTimes
+	r.lastCheck = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *certReloader) stat() ([]time.Time, error) {
+	var times []time.Time
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f == "" {
+			continue
+		}
+
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+
+		times = append(times, fi.ModTime())
+	}
+
+	return times, nil
+}
+
+func (r *certReloader) log() Logger {
+	if r.logger != nil {
+		return r.logger
+	}
+
+	return defaultLogger
+}
+
+// maybeReload reloads the files if they have changed since they were last
+// loaded. Errors are logged and the previous certificates kept in use
+func (r *certReloader) maybeReload() {
+	r.mu.RLock()
+	due := time.Since(r.lastCheck) > certCheckInterval
+	r.mu.RUnlock()
+
+	if !due {
+		return
+	}
+
+	modTimes, err := r.stat()
+
+	r.mu.Lock()
+	r.lastCheck = time.Now()
+	changed := err == nil && !equalTimes(modTimes, r.modTimes)
+	r.mu.Unlock()
+
+	if err != nil {
+		r.log().Error("lile: couldn't check certificates for changes", "error", err)
+		return
+	}
+
+	if !changed {
+		return
+	}
+
+	if err := r.load(); err != nil {
+		r.log().Error("lile: couldn't reload certificates", "error", err)
+		return
+	}
+
+	r.log().Info("lile: reloaded certificates", "cert", r.certFile)
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.maybeReload()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) certPool() *x509.CertPool {
+	r.maybeReload()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pool
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
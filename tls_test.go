@@ -0,0 +1,240 @@
+package lile
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// writeCert writes a self signed certificate for 127.0.0.1 that can also
+// act as its own CA
+func writeCert(t *testing.T, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return certFile, keyFile
+}
+
+// writeLeafCert writes a certificate for localhost signed by the CA, that
+// is only valid for usage
+func writeLeafCert(t *testing.T, dir, name, caCertFile, caKeyFile string, usage x509.ExtKeyUsage) (string, string) {
+	ca, err := tls.LoadX509KeyPair(caCertFile, caKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, ca.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return certFile, keyFile
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	s := testService(t, "tls")
+	s.Config.TLSCert, s.Config.TLSKey = writeCert(t, dir, "tls")
+	s.Config.TLSClientCA = s.Config.TLSCert
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+
+	client := ClientConfig{TLSRootCA: s.Config.TLSCert, TLSCert: s.Config.TLSCert, TLSKey: s.Config.TLSKey}
+	cfg, err := client.TLSConfig()
+	assert.Nil(t, err)
+	cfg.ServerName = "localhost"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Config.Address(),
+		grpc.WithTransportCredentials(credentials.NewTLS(cfg)), grpc.WithBlock())
+	assert.Nil(t, err)
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	conn.Close()
+
+	// Without a client certificate the handshake is rejected
+	noCert := cfg.Clone()
+	noCert.GetClientCertificate = nil
+	conn, err = grpc.DialContext(ctx, s.Config.Address(),
+		grpc.WithTransportCredentials(credentials.NewTLS(noCert)))
+	assert.Nil(t, err)
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NotNil(t, err)
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestCertReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCert(t, dir, "first")
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	assert.Nil(t, r.load())
+
+	first, _ := r.getCertificate(nil)
+
+	writeCert(t, dir, "second")
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, later, later)
+
+	// Force the next call to check the files
+	r.lastCheck = time.Time{}
+	second, _ := r.getCertificate(nil)
+
+	assert.NotEqual(t, first.Certificate[0], second.Certificate[0])
+}
+
+func TestClientTransportOptionFailsClosed(t *testing.T) {
+	s := testService(t, "plaintext")
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	// The global service has client TLS configured, but the files are missing
+	defer func(c ClientConfig) { service.ClientConfig = c }(service.ClientConfig)
+	service.ClientConfig.TLSCert = "/nonexistent/tls.crt"
+	service.ClientConfig.TLSKey = "/nonexistent/tls.key"
+
+	conn, err := grpc.Dial(s.Config.Address(), ClientTransportOption())
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The call must not reach the plaintext server
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NotNil(t, err)
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
+
+func TestClientTransportOptionMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lile-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// Servers and clients have certificates from different CAs, which are
+	// only valid for their side of the connection
+	os.Mkdir(filepath.Join(dir, "server-ca"), 0700)
+	os.Mkdir(filepath.Join(dir, "client-ca"), 0700)
+	serverCA, serverCAKey := writeCert(t, filepath.Join(dir, "server-ca"), "server-ca")
+	clientCA, clientCAKey := writeCert(t, filepath.Join(dir, "client-ca"), "client-ca")
+
+	s := testService(t, "client-tls")
+	s.Config.TLSCert, s.Config.TLSKey = writeLeafCert(t, dir, "server", serverCA, serverCAKey, x509.ExtKeyUsageServerAuth)
+	s.Config.TLSClientCA = clientCA
+
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run() }()
+	waitForState(t, s, StateServing)
+
+	defer func(c ClientConfig) { service.ClientConfig = c }(service.ClientConfig)
+	service.ClientConfig.TLSRootCA = serverCA
+	service.ClientConfig.TLSCert, service.ClientConfig.TLSKey = writeLeafCert(t, dir, "client", clientCA, clientCAKey, x509.ExtKeyUsageClientAuth)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.Dial("localhost:"+strconv.Itoa(s.Config.Port), ClientTransportOption())
+	assert.Nil(t, err)
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	conn.Close()
+
+	s.Shutdown()
+	assert.Nil(t, <-errs)
+}
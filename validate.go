@@ -0,0 +1,111 @@
+package lile
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validator is implemented by messages generated by protoc-gen-validate
+type validator interface {
+	Validate() error
+}
+
+// allValidator is implemented by newer protoc-gen-validate messages and
+// reports every violation rather than the first
+type allValidator interface {
+	ValidateAll() error
+}
+
+// fieldError is implemented by protoc-gen-validate validation errors
+type fieldError interface {
+	Field() string
+	Reason() string
+}
+
+// multiError is implemented by the errors returned from ValidateAll
+type multiError interface {
+	AllErrors() []error
+}
+
+// ValidationUnaryInterceptor rejects requests that fail their Validate or
+// ValidateAll method with codes.InvalidArgument, listing each violation in
+// a BadRequest detail
+func ValidationUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// ValidationStreamInterceptor validates every message received on a stream
+// like ValidationUnaryInterceptor
+func ValidationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return handler(srv, &validatingServerStream{ss})
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return validate(m)
+}
+
+// validate returns an InvalidArgument status for an invalid message, or nil
+// when the message is valid or can't be validated
+func validate(m interface{}) error {
+	var err error
+	switch v := m.(type) {
+	case allValidator:
+		err = v.ValidateAll()
+	case validator:
+		err = v.Validate()
+	default:
+		return nil
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	if me, ok := err.(multiError); ok {
+		errs = me.AllErrors()
+	}
+
+	br := &errdetails.BadRequest{}
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		v := &errdetails.BadRequest_FieldViolation{Description: e.Error()}
+		if fe, ok := e.(fieldError); ok {
+			v.Field = fe.Field()
+			v.Description = fe.Reason()
+		}
+
+		br.FieldViolations = append(br.FieldViolations, v)
+		msgs = append(msgs, e.Error())
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(msgs, "; "))
+	if ds, err := st.WithDetails(br); err == nil {
+		st = ds
+	}
+
+	return st.Err()
+}
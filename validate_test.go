@@ -0,0 +1,127 @@
+package lile
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldValidationError mirrors the errors generated by protoc-gen-validate
+type fieldValidationError struct {
+	field, reason string
+}
+
+func (e fieldValidationError) Field() string  { return e.field }
+func (e fieldValidationError) Reason() string { return e.reason }
+func (e fieldValidationError) Error() string {
+	return "invalid " + e.field + ": " + e.reason
+}
+
+type validationMultiError []error
+
+func (m validationMultiError) Error() string {
+	msgs := []string{}
+	for _, e := range m {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (m validationMultiError) AllErrors() []error { return m }
+
+type validatedRequest struct {
+	name, email string
+}
+
+func (r validatedRequest) Validate() error {
+	return errors.New("ValidateAll should be preferred")
+}
+
+func (r validatedRequest) ValidateAll() error {
+	var errs validationMultiError
+	if r.name == "" {
+		errs = append(errs, fieldValidationError{"Name", "value is required"})
+	}
+	if !strings.Contains(r.email, "@") {
+		errs = append(errs, fieldValidationError{"Email", "value must be a valid email address"})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+type simpleRequest struct{ valid bool }
+
+func (r simpleRequest) Validate() error {
+	if !r.valid {
+		return errors.New("request is invalid")
+	}
+	return nil
+}
+
+func TestValidationUnaryInterceptor(t *testing.T) {
+	intercept := ValidationUnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	res, err := intercept(context.Background(), validatedRequest{"alex", "alex@example.com"},
+		&grpc.UnaryServerInfo{}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", res)
+
+	_, err = intercept(context.Background(), validatedRequest{}, &grpc.UnaryServerInfo{}, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	if assert.Len(t, st.Details(), 1) {
+		br := st.Details()[0].(*errdetails.BadRequest)
+		if assert.Len(t, br.FieldViolations, 2) {
+			assert.Equal(t, "Name", br.FieldViolations[0].Field)
+			assert.Equal(t, "value is required", br.FieldViolations[0].Description)
+			assert.Equal(t, "Email", br.FieldViolations[1].Field)
+		}
+	}
+
+	_, err = intercept(context.Background(), simpleRequest{}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "request is invalid", status.Convert(err).Message())
+
+	// Messages without validation pass through
+	res, err = intercept(context.Background(), struct{}{}, &grpc.UnaryServerInfo{}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", res)
+}
+
+type recvStream struct {
+	grpc.ServerStream
+	msgs []simpleRequest
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	*(m.(*simpleRequest)) = s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestValidationStreamInterceptor(t *testing.T) {
+	ss := &recvStream{msgs: []simpleRequest{{valid: true}, {valid: false}}}
+
+	err := ValidationStreamInterceptor()(nil, ss, &grpc.StreamServerInfo{},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			var req simpleRequest
+			assert.Nil(t, stream.RecvMsg(&req))
+			return stream.RecvMsg(&req)
+		})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}